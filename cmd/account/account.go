@@ -1,7 +1,6 @@
 package account
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
@@ -53,7 +52,7 @@ func fetchAccounts(cmd *cobra.Command) ([]accountEntry, error) {
 	}
 
 	var resp accountsResponse
-	_, err = sdkclient.DoRaw(context.Background(), httpClient, token, "GET", "/accounts", nil, &resp)
+	_, err = sdkclient.DoRaw(cmd.Context(), httpClient, token, "GET", "/accounts", nil, &resp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch accounts: %w", err)
 	}
@@ -68,22 +67,17 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	activeAccountID := config.GetAccountID(cmdutil.ProfileFlag(cmd))
 
-	jsonFlag := cmdutil.JSONFlag(cmd)
-	if jsonFlag {
-		return output.JSON(accounts)
-	}
-
-	var rows [][]string
-	for _, a := range accounts {
-		name := a.Name
-		if a.ID == activeAccountID {
-			name += " (active)"
-		}
-		rows = append(rows, []string{a.ID, name, a.Status})
-	}
-
-	output.Table([]string{"ID", "Name", "Status"}, rows)
-	return nil
+	cols := output.NewColumnSet([]string{"id", "name", "status"}, []output.Column[accountEntry]{
+		{Name: "id", Header: "ID", Value: func(a accountEntry) string { return a.ID }},
+		{Name: "name", Header: "Name", Value: func(a accountEntry) string {
+			if a.ID == activeAccountID {
+				return a.Name + " (active)"
+			}
+			return a.Name
+		}},
+		{Name: "status", Header: "Status", Value: func(a accountEntry) string { return a.Status }},
+	})
+	return cmdutil.PrintList(cmd, cols, output.Items(accounts))
 }
 
 func runSwitch(cmd *cobra.Command, args []string) error {
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "api <method> <path>",
+	Short: "Make an authenticated API request",
+	Long: `Make an authenticated request to any MailerLite API endpoint and print the
+JSON response. The path is relative to the API base URL, e.g. "/subscribers".
+
+The request uses the selected profile's token, account and base URL, and is
+retried and rate limited like every other command.
+
+Fields given with -f (strings) or -F (typed: numbers, true, false, null, or
+@file for a file's contents) are sent as a JSON object body, or as query
+parameters for GET. --input sends a JSON file as the body instead.
+
+With --paginate, pages are followed through links.next or meta.next_cursor
+and the data arrays of all pages are printed as one array.`,
+	Example: `  mailerlite api GET /subscribers -f filter[status]=active -F limit=10
+  mailerlite api POST /groups -f name=Newsletter
+  mailerlite api PUT /subscribers/123 --input subscriber.json
+  mailerlite api GET /groups --paginate --query '[].name'
+  mailerlite api GET /automations -H "Accept-Language: lt"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAPI,
+}
+
+func init() {
+	Cmd.Flags().StringArrayP("raw-field", "f", nil, "add a string parameter as key=value")
+	Cmd.Flags().StringArrayP("field", "F", nil, "add a typed parameter as key=value (numbers, true, false, null, @file)")
+	Cmd.Flags().String("input", "", "JSON file to send as the request body (- for stdin)")
+	Cmd.Flags().StringArrayP("header", "H", nil, "add a request header as \"Key: Value\"")
+	Cmd.Flags().Bool("paginate", false, "fetch all pages and print their data as one array")
+}
+
+var apiMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+func runAPI(c *cobra.Command, args []string) error {
+	method := strings.ToUpper(args[0])
+	if !slices.Contains(apiMethods, method) {
+		return fmt.Errorf("invalid method %q: use one of %s", args[0], strings.Join(apiMethods, ", "))
+	}
+	path, err := sdkclient.RelativePath(args[1])
+	if err != nil {
+		return err
+	}
+
+	rawFields, _ := c.Flags().GetStringArray("raw-field")
+	typedFields, _ := c.Flags().GetStringArray("field")
+	params, err := parseParams(rawFields, typedFields)
+	if err != nil {
+		return err
+	}
+	headerValues, _ := c.Flags().GetStringArray("header")
+	header, err := parseHeaders(headerValues)
+	if err != nil {
+		return err
+	}
+
+	var body interface{}
+	inputPath, _ := c.Flags().GetString("input")
+	switch {
+	case inputPath != "":
+		if body, err = readInput(inputPath); err != nil {
+			return err
+		}
+		path = withQuery(path, params)
+	case method == http.MethodGet:
+		path = withQuery(path, params)
+	case len(params) > 0:
+		body = params
+	}
+
+	paginate, _ := c.Flags().GetBool("paginate")
+	if paginate && method != http.MethodGet {
+		return fmt.Errorf("--paginate can only be used with GET")
+	}
+
+	httpClient, token, err := cmdutil.RawHTTPClient(c)
+	if err != nil {
+		return err
+	}
+
+	if !paginate {
+		var result interface{}
+		if _, err := sdkclient.DoRawWithHeaders(c.Context(), httpClient, token, method, path, header, body, &result); err != nil {
+			return err
+		}
+		if result == nil {
+			return nil
+		}
+		return cmdutil.Print(c, result)
+	}
+
+	items := []interface{}{}
+	for item, err := range sdkclient.IterateRaw(c.Context(), httpClient, token, path, header) {
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+	return cmdutil.Print(c, items)
+}
+
+// withQuery sets params as query parameters of path, replacing existing
+// values of the same keys.
+func withQuery(path string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return path
+	}
+	base, rawQuery, _ := strings.Cut(path, "?")
+	query, _ := url.ParseQuery(rawQuery)
+	for k, v := range params {
+		if v == nil {
+			query.Set(k, "")
+		} else {
+			query.Set(k, fmt.Sprint(v))
+		}
+	}
+	return base + "?" + query.Encode()
+}
+
+// parseParams parses -f and -F key=value pairs.
+func parseParams(raw, typed []string) (map[string]interface{}, error) {
+	params := make(map[string]interface{}, len(raw)+len(typed))
+	for _, pair := range raw {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid field %q: expected key=value", pair)
+		}
+		params[key] = value
+	}
+	for _, pair := range typed {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid field %q: expected key=value", pair)
+		}
+		v, err := typedValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", key, err)
+		}
+		params[key] = v
+	}
+	return params, nil
+}
+
+// typedValue converts a -F value to a JSON number, boolean or null, or reads
+// it from a file for @path.
+func typedValue(value string) (interface{}, error) {
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return string(data), nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+	return value, nil
+}
+
+func parseHeaders(values []string) (http.Header, error) {
+	header := http.Header{}
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid header %q: expected \"Key: Value\"", v)
+		}
+		header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	return header, nil
+}
+
+// readInput reads a JSON request body from path, or stdin if path is "-".
+func readInput(path string) (json.RawMessage, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close() //nolint:errcheck
+		r = f
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("input is not valid JSON")
+	}
+	return data, nil
+}
@@ -0,0 +1,187 @@
+package apply
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/manifest"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Converge an account on a declarative configuration",
+	Long: `Compare the fields, groups, segments and webhooks described in a YAML
+manifest with those in the account, show the changes needed to make the
+account match, and make them.
+
+  fields:
+    - name: City
+      type: text
+  groups:
+    - name: Newsletter
+  segments:
+    - name: Active buyers
+  webhooks:
+    - name: CRM sync
+      url: https://crm.example.com/hooks/mailerlite
+      events: [subscriber.created, subscriber.updated]
+      enabled: true
+
+Items are matched by name. Missing items are created and webhooks whose URL,
+events or enabled state differ are updated. Items that are in the account but
+not in the manifest are left alone unless --prune is given, and only for the
+kinds the manifest lists: an empty "webhooks: []" prunes every webhook, while
+leaving "webhooks" out does not manage webhooks at all.
+
+Some differences cannot be resolved through the API: a field's type cannot be
+changed and segments cannot be created. They are reported as problems, and an
+account with problems is not changed.
+
+With --plan, the changes are only shown. With --profiles, the manifest is
+applied to each profile's account in turn, stopping at the first failure.`,
+	Example: `  mailerlite apply -f account.yaml --plan
+  mailerlite apply -f account.yaml
+  mailerlite apply -f account.yaml --prune --yes
+  mailerlite apply -f account.yaml --profiles staging,production`,
+	Args: cobra.NoArgs,
+	RunE: runApply,
+}
+
+func init() {
+	Cmd.Flags().StringP("file", "f", "", "manifest YAML file, or - for stdin (required)")
+	Cmd.Flags().Bool("plan", false, "show the changes without making them")
+	Cmd.Flags().Bool("prune", false, "delete items that are not in the manifest")
+	Cmd.Flags().StringSlice("profiles", nil, "apply to each of these profiles instead of --profile")
+}
+
+// profileResult is the reported outcome for one profile.
+type profileResult struct {
+	Profile string `json:"profile,omitempty"`
+	*manifest.Plan
+	Applied int `json:"applied"`
+}
+
+func runApply(c *cobra.Command, _ []string) error {
+	filePath, _ := c.Flags().GetString("file")
+	filePath, err := prompt.RequireArg(filePath, "file", "Path to manifest YAML file")
+	if err != nil {
+		return err
+	}
+	desired, err := manifest.Load(filePath)
+	if err != nil {
+		return err
+	}
+
+	planOnly, _ := c.Flags().GetBool("plan")
+	prune, _ := c.Flags().GetBool("prune")
+	profiles, _ := c.Flags().GetStringSlice("profiles")
+	if len(profiles) == 0 {
+		profiles = []string{cmdutil.ProfileFlag(c)}
+	}
+
+	// Structured output is printed at the end, so the plan goes to stderr.
+	var w io.Writer = os.Stdout
+	if cmdutil.StructuredOutput(c) {
+		w = os.Stderr
+	}
+
+	results := []profileResult{}
+	var applyErr error
+	for _, profile := range profiles {
+		res, err := applyProfile(c, w, profile, desired, planOnly, prune)
+		if res != nil {
+			results = append(results, *res)
+		}
+		if err != nil {
+			if profile != "" {
+				err = fmt.Errorf("profile %s: %w", profile, err)
+			}
+			applyErr = err
+			break
+		}
+	}
+
+	if cmdutil.StructuredOutput(c) {
+		if err := cmdutil.Print(c, results); err != nil {
+			return err
+		}
+	}
+	return applyErr
+}
+
+// applyProfile plans the changes for one profile's account, prints the plan
+// and, unless planOnly is set, makes the changes once confirmed.
+func applyProfile(c *cobra.Command, w io.Writer, profile string, desired *manifest.Manifest, planOnly, prune bool) (*profileResult, error) {
+	ml, err := cmdutil.NewSDKClientForProfile(c, profile)
+	if err != nil {
+		return nil, err
+	}
+	current, err := manifest.Fetch(c.Context(), ml)
+	if err != nil {
+		return nil, err
+	}
+	plan := manifest.NewPlan(desired, current, prune)
+	res := &profileResult{Profile: profile, Plan: plan}
+
+	if profile != "" {
+		fmt.Fprintf(w, "Profile %s:\n", profile) //nolint:errcheck
+	}
+	printPlan(w, plan)
+
+	if len(plan.Problems) > 0 {
+		return res, fmt.Errorf("%d problem(s) must be resolved before applying", len(plan.Problems))
+	}
+	if planOnly || len(plan.Changes) == 0 {
+		return res, nil
+	}
+
+	if !cmdutil.YesFlag(c) && prompt.IsInteractive() {
+		ok, err := prompt.Confirm(fmt.Sprintf("Apply %d change(s)?", len(plan.Changes)))
+		if err != nil {
+			return res, err
+		}
+		if !ok {
+			return res, nil
+		}
+	}
+
+	res.Applied, err = plan.Apply(c.Context(), ml)
+	if err != nil {
+		return res, fmt.Errorf("%w (%d of %d changes applied)", err, res.Applied, len(plan.Changes))
+	}
+	if !cmdutil.StructuredOutput(c) {
+		output.Success(fmt.Sprintf("Applied %d change(s).", res.Applied))
+	}
+	return res, nil
+}
+
+var actionSymbols = map[manifest.Action]string{
+	manifest.ActionCreate: "+",
+	manifest.ActionUpdate: "~",
+	manifest.ActionDelete: "-",
+}
+
+// printPlan prints one line per change, its details indented below, and the
+// problems.
+func printPlan(w io.Writer, plan *manifest.Plan) {
+	for _, ch := range plan.Changes {
+		fmt.Fprintf(w, "  %s %s %q\n", actionSymbols[ch.Action], ch.Kind, ch.Name) //nolint:errcheck
+		for _, d := range ch.Details {
+			fmt.Fprintf(w, "      %s\n", d) //nolint:errcheck
+		}
+	}
+	for _, p := range plan.Problems {
+		fmt.Fprintf(w, "  ! %s\n", p) //nolint:errcheck
+	}
+	if len(plan.Changes) == 0 && len(plan.Problems) == 0 {
+		fmt.Fprintln(w, "No changes. The account matches the manifest.") //nolint:errcheck
+		return
+	}
+	fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to delete.\n", //nolint:errcheck
+		plan.Count(manifest.ActionCreate), plan.Count(manifest.ActionUpdate), plan.Count(manifest.ActionDelete))
+}
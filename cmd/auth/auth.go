@@ -11,14 +11,14 @@ import (
 	"net"
 	"net/http"
 	"net/url"
-	"os/exec"
-	"runtime"
 	"strings"
 	"time"
 
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
 	"github.com/mailerlite/mailerlite-cli/internal/config"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
 	"github.com/spf13/cobra"
 )
 
@@ -99,13 +99,14 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		if token == "" {
 			return fmt.Errorf("token cannot be empty")
 		}
-		cfg.Profiles[profName] = config.Profile{APIToken: token}
+		cfg.Profiles[profName] = config.Profile{APIToken: token, BaseURL: cfg.Profiles[profName].BaseURL}
 
 	case "oauth":
-		prof, err := oauthBrowserFlow()
+		prof, err := oauthBrowserFlow(cmd.Context())
 		if err != nil {
 			return fmt.Errorf("OAuth login failed: %w", err)
 		}
+		prof.BaseURL = cfg.Profiles[profName].BaseURL
 		cfg.Profiles[profName] = prof
 
 	default:
@@ -121,7 +122,7 @@ func runLogin(cmd *cobra.Command, args []string) error {
 
 	// After OAuth login, prompt to select an account.
 	if method == "oauth" {
-		if err := selectAccount(cfg, profName); err != nil {
+		if err := selectAccount(cmd, cfg, profName); err != nil {
 			fmt.Printf("Warning: could not set account: %v\n", err)
 		}
 	}
@@ -189,14 +190,14 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	jsonFlag, _ := cmd.Root().PersistentFlags().GetBool("json")
-	if jsonFlag {
-		return output.JSON(map[string]interface{}{
+	if cmdutil.StructuredOutput(cmd) {
+		return cmdutil.Print(cmd, map[string]interface{}{
 			"profile":    name,
 			"has_token":  prof.APIToken != "",
 			"has_oauth":  prof.OAuthToken != "",
 			"expires_at": prof.OAuthExpiresAt,
 			"account_id": prof.AccountID,
+			"base_url":   config.GetBaseURL(name),
 		})
 	}
 
@@ -227,6 +228,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	if prof.AccountID != "" {
 		rows = append(rows, []string{"Account ID", prof.AccountID})
 	}
+	if base := config.GetBaseURL(name); base != "" {
+		rows = append(rows, []string{"Base URL", base})
+	}
 
 	output.Table(
 		[]string{"Field", "Value"},
@@ -238,7 +242,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 // oauthBrowserFlow performs the full OAuth 2.0 Authorization Code flow with PKCE.
 // It starts a local HTTP server, opens the browser to the authorize URL,
 // captures the authorization code, and exchanges it for access/refresh tokens.
-func oauthBrowserFlow() (config.Profile, error) {
+func oauthBrowserFlow(ctx context.Context) (config.Profile, error) {
 	state, err := randomHex(16)
 	if err != nil {
 		return config.Profile{}, err
@@ -298,7 +302,7 @@ func oauthBrowserFlow() (config.Profile, error) {
 
 	fmt.Printf("Opening browser for authentication...\n")
 	fmt.Printf("If the browser doesn't open, visit:\n%s\n\n", authURL)
-	openBrowser(authURL)
+	cmdutil.OpenBrowser(authURL)
 
 	var code string
 	select {
@@ -307,6 +311,8 @@ func oauthBrowserFlow() (config.Profile, error) {
 		return config.Profile{}, err
 	case <-time.After(5 * time.Minute):
 		return config.Profile{}, fmt.Errorf("authentication timed out after 5 minutes")
+	case <-ctx.Done():
+		return config.Profile{}, ctx.Err()
 	}
 
 	// Exchange authorization code for tokens.
@@ -375,21 +381,6 @@ func generatePKCE() (verifier, challenge string, err error) {
 	return verifier, challenge, nil
 }
 
-func openBrowser(url string) {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-	}
-	if cmd != nil {
-		_ = cmd.Start()
-	}
-}
-
 func randomHex(n int) (string, error) {
 	b := make([]byte, n)
 	if _, err := rand.Read(b); err != nil {
@@ -410,33 +401,20 @@ type accountsResponse struct {
 
 // selectAccount fetches the user's accounts and prompts to select one.
 // If there's only one account, it's selected automatically.
-func selectAccount(cfg *config.Config, profName string) error {
+func selectAccount(cmd *cobra.Command, cfg *config.Config, profName string) error {
 	prof := cfg.Profiles[profName]
 	token := prof.OAuthToken
 	if token == "" {
 		return nil
 	}
 
-	req, err := http.NewRequest("GET", "https://connect.mailerlite.com/api/accounts", nil)
+	httpClient, err := cmdutil.NewHTTPClient(cmd, profName)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to fetch accounts: %w", err)
-	}
-	defer resp.Body.Close() //nolint:errcheck
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to fetch accounts (HTTP %d)", resp.StatusCode)
-	}
-
 	var accounts accountsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&accounts); err != nil {
-		return fmt.Errorf("failed to parse accounts: %w", err)
+	if _, err := sdkclient.DoRaw(cmd.Context(), httpClient, token, http.MethodGet, "/accounts", nil, &accounts); err != nil {
+		return fmt.Errorf("failed to fetch accounts: %w", err)
 	}
 
 	if len(accounts.Data) == 0 {
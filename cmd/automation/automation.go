@@ -3,10 +3,9 @@ package automation
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
-	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
 	"github.com/mailerlite/mailerlite-go"
 	"github.com/spf13/cobra"
@@ -48,9 +47,9 @@ func runList(c *cobra.Command, _ []string) error {
 	limit, _ := c.Flags().GetInt("limit")
 	enabled, _ := c.Flags().GetString("enabled")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	automations, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Automation, bool, error) {
+	automations := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Automation, bool, error) {
 		var filters []mailerlite.Filter
 		if enabled != "" {
 			filters = append(filters, mailerlite.Filter{Name: "enabled", Value: enabled})
@@ -70,35 +69,9 @@ func runList(c *cobra.Command, _ []string) error {
 		}
 
 		return root.Data, !root.Links.IsLastPage(), nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(automations)
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	headers := []string{"ID", "NAME", "ENABLED", "EMAILS", "COMPLETED", "IN QUEUE"}
-	var rows [][]string
-
-	for _, a := range automations {
-		enabledStr := "No"
-		if a.Enabled {
-			enabledStr = "Yes"
-		}
-		rows = append(rows, []string{
-			a.ID,
-			output.Truncate(a.Name, 40),
-			enabledStr,
-			strconv.Itoa(a.EmailsCount),
-			strconv.Itoa(a.Stats.CompletedSubscribersCount),
-			strconv.Itoa(a.Stats.SubscribersInQueueCount),
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Automation, automations)
 }
 
 // --- get ---
@@ -116,14 +89,14 @@ func runGet(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Automation.Get(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	d := result.Data
@@ -175,9 +148,9 @@ func runSubscribers(c *cobra.Command, args []string) error {
 
 	limit, _ := c.Flags().GetInt("limit")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	subscribers, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.AutomationSubscriber, bool, error) {
+	subscribers := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.AutomationSubscriber, bool, error) {
 		opts := &mailerlite.ListAutomationSubscriberOptions{
 			AutomationID: args[0],
 			Page:         page,
@@ -190,27 +163,7 @@ func runSubscribers(c *cobra.Command, args []string) error {
 		}
 
 		return root.Data, !root.Links.IsLastPage(), nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(subscribers)
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	headers := []string{"ID", "EMAIL", "STATUS", "DATE"}
-	var rows [][]string
-
-	for _, s := range subscribers {
-		rows = append(rows, []string{
-			s.ID,
-			s.Subscriber.Email,
-			s.Status,
-			s.Date,
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.AutomationSubscriber, subscribers)
 }
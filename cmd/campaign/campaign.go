@@ -3,10 +3,20 @@ package campaign
 import (
 	"context"
 	"fmt"
+	"iter"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/mailerlite/mailerlite-cli/internal/bulk"
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
+	"github.com/mailerlite/mailerlite-cli/internal/content"
+	"github.com/mailerlite/mailerlite-cli/internal/datetime"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
@@ -25,8 +35,13 @@ func init() {
 	Cmd.AddCommand(getCmd)
 	Cmd.AddCommand(createCmd)
 	Cmd.AddCommand(updateCmd)
+	Cmd.AddCommand(duplicateCmd)
+	Cmd.AddCommand(previewCmd)
+	Cmd.AddCommand(lintCmd)
 	Cmd.AddCommand(scheduleCmd)
 	Cmd.AddCommand(cancelCmd)
+	Cmd.AddCommand(resendCmd)
+	Cmd.AddCommand(reportCmd)
 	Cmd.AddCommand(subscribersCmd)
 	Cmd.AddCommand(languagesCmd)
 	Cmd.AddCommand(deleteCmd)
@@ -45,6 +60,7 @@ func init() {
 	createCmd.Flags().String("content", "", "email HTML content")
 	createCmd.Flags().StringSlice("groups", nil, "group IDs")
 	createCmd.Flags().StringSlice("segments", nil, "segment IDs")
+	addContentFlags(createCmd)
 
 	// update flags
 	updateCmd.Flags().String("name", "", "campaign name")
@@ -55,6 +71,14 @@ func init() {
 	updateCmd.Flags().String("content", "", "email HTML content")
 	updateCmd.Flags().StringSlice("groups", nil, "group IDs")
 	updateCmd.Flags().StringSlice("segments", nil, "segment IDs")
+	addContentFlags(updateCmd)
+
+	// duplicate flags
+	duplicateCmd.Flags().String("name", "", "name of the copy (default the original name followed by \"(copy)\")")
+	duplicateCmd.Flags().String("subject", "", "email subject of the copy (default the original subject)")
+
+	// preview flags
+	previewCmd.Flags().Bool("open", false, "open the HTML in a browser instead of printing it as text")
 
 	// schedule flags
 	scheduleCmd.Flags().String("delivery", "instant", "delivery type (instant, scheduled)")
@@ -62,9 +86,36 @@ func init() {
 	scheduleCmd.Flags().String("hours", "", "schedule hours (00-23)")
 	scheduleCmd.Flags().String("minutes", "", "schedule minutes (00-59)")
 	scheduleCmd.Flags().Int("timezone-id", 0, "timezone ID")
+	scheduleCmd.Flags().String("at", "", "send time, e.g. \"2026-11-02 09:30\" or \"tomorrow 9am\" (implies --delivery scheduled)")
+	scheduleCmd.Flags().String("tz", "", "timezone of --at by name, e.g. Europe/Vilnius (default local time)")
+	scheduleCmd.Flags().Bool("force", false, "schedule even if the campaign fails lint")
+	scheduleCmd.MarkFlagsMutuallyExclusive("at", "date")
+	scheduleCmd.MarkFlagsMutuallyExclusive("at", "hours")
+	scheduleCmd.MarkFlagsMutuallyExclusive("at", "minutes")
+	scheduleCmd.MarkFlagsMutuallyExclusive("tz", "timezone-id")
+
+	// resend flags
+	resendCmd.Flags().String("to", "non-openers", "who to resend to (non-openers)")
+	resendCmd.Flags().String("after", "48h", "how long after the original to send, e.g. 48h or 3d")
+	resendCmd.Flags().String("name", "", "name of the resend campaign (default the original name followed by \"(resend)\")")
+	resendCmd.Flags().String("subject", "", "email subject of the resend (default the original subject)")
+	resendCmd.Flags().Int("concurrency", bulk.DefaultConcurrency, "number of subscribers to add to the group at once")
+	resendCmd.Flags().Bool("batch", false, fmt.Sprintf("add subscribers through the batch endpoint, %d per call", sdkclient.MaxBatchSize))
+
+	// report flags
+	reportCmd.Flags().Bool("compare", false, "tabulate all campaigns sent in a date range")
+	reportCmd.Flags().String("since", "", "start of the --compare range (YYYY-MM-DD or unix timestamp, default 7 days ago)")
+	reportCmd.Flags().String("until", "", "end of the --compare range (YYYY-MM-DD or unix timestamp, default now)")
 
 	// subscribers flags
-	subscribersCmd.Flags().Int("limit", 25, "maximum number of subscribers to return (0 = all)")
+	subscribersCmd.Flags().Int("limit", 25, "maximum number of subscribers to return (0 = all, the default with --to-group)")
+	subscribersCmd.Flags().String("type", "", "only subscribers who opened, unopened, clicked, unsubscribed, forwarded, bounced, hardbounced, softbounced or junk")
+	subscribersCmd.Flags().String("to-group", "", "add the matching subscribers to this group instead of listing them")
+	subscribersCmd.Flags().Int("concurrency", bulk.DefaultConcurrency, "number of subscribers to add to the group at once")
+	subscribersCmd.Flags().Bool("batch", false, fmt.Sprintf("add subscribers through the batch endpoint, %d per call", sdkclient.MaxBatchSize))
+
+	// delete flags
+	cmdutil.AddBulkFlags(deleteCmd, "campaigns")
 }
 
 // --- list ---
@@ -85,9 +136,9 @@ func runList(c *cobra.Command, _ []string) error {
 	status, _ := c.Flags().GetString("status")
 	campaignType, _ := c.Flags().GetString("type")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	campaigns, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Campaign, bool, error) {
+	campaigns := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Campaign, bool, error) {
 		var filters []mailerlite.Filter
 		if status != "" {
 			filters = append(filters, mailerlite.Filter{Name: "status", Value: status})
@@ -110,32 +161,9 @@ func runList(c *cobra.Command, _ []string) error {
 		}
 
 		return root.Data, !root.Links.IsLastPage(), nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(campaigns)
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	headers := []string{"ID", "NAME", "TYPE", "STATUS", "SENT", "OPENS", "CLICKS"}
-	var rows [][]string
-
-	for _, camp := range campaigns {
-		rows = append(rows, []string{
-			camp.ID,
-			output.Truncate(camp.Name, 40),
-			camp.Type,
-			camp.Status,
-			strconv.Itoa(camp.Stats.Sent),
-			strconv.Itoa(camp.Stats.OpensCount),
-			strconv.Itoa(camp.Stats.ClicksCount),
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Campaign, campaigns)
 }
 
 // --- get ---
@@ -153,14 +181,14 @@ func runGet(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Campaign.Get(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	d := result.Data
@@ -197,17 +225,22 @@ func runGet(c *cobra.Command, args []string) error {
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a campaign",
-	RunE:  runCreate,
+	Long: `Create a campaign.
+
+The HTML content can be given with --content, or read from a file with
+--content-file, and the plain text version from a file with --plain-file.
+With --vars, the content is treated as a template and the variables from the
+YAML or JSON file are filled in: {{.name}} or {{name}} is replaced with the
+value of name, and Go template actions such as {{range .items}} are
+available. MailerLite merge tags such as {$name} are left for MailerLite.`,
+	Example: `  mailerlite campaign create --name "March news" --subject "News" --from news@example.com --from-name Example --content-file email.html
+  mailerlite campaign create --name "March news" --subject "News" --from news@example.com --from-name Example --content-file email.html --plain-file email.txt --vars march.yaml`,
+	RunE: runCreate,
 }
 
 func runCreate(c *cobra.Command, _ []string) error {
-	ml, err := cmdutil.NewSDKClient(c)
-	if err != nil {
-		return err
-	}
-
 	name, _ := c.Flags().GetString("name")
-	name, err = prompt.RequireArg(name, "name", "Campaign name")
+	name, err := prompt.RequireArg(name, "name", "Campaign name")
 	if err != nil {
 		return err
 	}
@@ -231,33 +264,40 @@ func runCreate(c *cobra.Command, _ []string) error {
 		return err
 	}
 
-	content, _ := c.Flags().GetString("content")
+	html, plain, err := emailContent(c)
+	if err != nil {
+		return err
+	}
 	groups, _ := c.Flags().GetStringSlice("groups")
 	segments, _ := c.Flags().GetStringSlice("segments")
 
-	ctx := context.Background()
-	opts := &mailerlite.CreateCampaign{
-		Name: name,
-		Type: campaignType,
-		Emails: []mailerlite.Emails{
+	opts := campaignRequest{
+		CreateCampaign: mailerlite.CreateCampaign{
+			Name:     name,
+			Type:     campaignType,
+			Groups:   groups,
+			Segments: segments,
+		},
+		Emails: []email{
 			{
-				Subject:  subject,
-				From:     from,
-				FromName: fromName,
-				Content:  content,
+				Emails: mailerlite.Emails{
+					Subject:  subject,
+					From:     from,
+					FromName: fromName,
+					Content:  html,
+				},
+				PlainText: plain,
 			},
 		},
-		Groups:   groups,
-		Segments: segments,
 	}
 
-	result, _, err := ml.Campaign.Create(ctx, opts)
+	result, err := saveCampaign(c, http.MethodPost, "/campaigns", opts)
 	if err != nil {
-		return sdkclient.WrapError(err)
+		return err
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Campaign created successfully. ID: " + result.Data.ID)
@@ -269,8 +309,14 @@ func runCreate(c *cobra.Command, _ []string) error {
 var updateCmd = &cobra.Command{
 	Use:   "update <campaign_id>",
 	Short: "Update a campaign",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runUpdate,
+	Long: `Update a campaign. Only the given flags are changed.
+
+The content flags work as for "campaign create", so a template kept in git
+can be pushed again after every change.`,
+	Example: `  mailerlite campaign update 123 --subject "March news, updated"
+  mailerlite campaign update 123 --content-file email.html --vars march.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUpdate,
 }
 
 func runUpdate(c *cobra.Command, args []string) error {
@@ -280,7 +326,7 @@ func runUpdate(c *cobra.Command, args []string) error {
 	}
 
 	// First get the existing campaign to preserve unchanged fields.
-	ctx := context.Background()
+	ctx := c.Context()
 	existing, _, err := ml.Campaign.Get(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
@@ -297,10 +343,10 @@ func runUpdate(c *cobra.Command, args []string) error {
 	}
 
 	// Build email from existing or flags.
-	var existingEmail mailerlite.Emails
+	var existingEmail email
 	if len(existing.Data.Emails) > 0 {
 		e := existing.Data.Emails[0]
-		existingEmail = mailerlite.Emails{
+		existingEmail.Emails = mailerlite.Emails{
 			Subject:  e.Subject,
 			From:     e.From,
 			FromName: e.FromName,
@@ -316,14 +362,24 @@ func runUpdate(c *cobra.Command, args []string) error {
 	if c.Flags().Changed("from-name") {
 		existingEmail.FromName, _ = c.Flags().GetString("from-name")
 	}
-	if c.Flags().Changed("content") {
-		existingEmail.Content, _ = c.Flags().GetString("content")
+
+	html, plain, err := emailContent(c)
+	if err != nil {
+		return err
+	}
+	if c.Flags().Changed("content") || c.Flags().Changed("content-file") {
+		existingEmail.Content = html
+	}
+	if c.Flags().Changed("plain-file") {
+		existingEmail.PlainText = plain
 	}
 
-	opts := &mailerlite.UpdateCampaign{
-		Name:   name,
-		Type:   campaignType,
-		Emails: []mailerlite.Emails{existingEmail},
+	opts := campaignRequest{
+		CreateCampaign: mailerlite.CreateCampaign{
+			Name: name,
+			Type: campaignType,
+		},
+		Emails: []email{existingEmail},
 	}
 
 	if c.Flags().Changed("groups") {
@@ -333,26 +389,281 @@ func runUpdate(c *cobra.Command, args []string) error {
 		opts.Segments, _ = c.Flags().GetStringSlice("segments")
 	}
 
-	result, _, err := ml.Campaign.Update(ctx, args[0], opts)
+	result, err := saveCampaign(c, http.MethodPut, "/campaigns/"+args[0], opts)
 	if err != nil {
-		return sdkclient.WrapError(err)
+		return err
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Campaign " + args[0] + " updated successfully.")
 	return nil
 }
 
+// campaignRequest is the body of a campaign create or update request. It is
+// sent without the SDK, whose emails have no plain text part.
+type campaignRequest struct {
+	mailerlite.CreateCampaign
+	Emails []email `json:"emails"`
+}
+
+type email struct {
+	mailerlite.Emails
+	PlainText string `json:"plain_text,omitempty"`
+}
+
+func saveCampaign(c *cobra.Command, method, path string, body campaignRequest) (*mailerlite.RootCampaign, error) {
+	httpClient, token, err := cmdutil.RawHTTPClient(c)
+	if err != nil {
+		return nil, err
+	}
+	var result mailerlite.RootCampaign
+	if _, err := sdkclient.DoRaw(c.Context(), httpClient, token, method, path, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func addContentFlags(cmd *cobra.Command) {
+	cmd.Flags().String("content-file", "", "file with the email HTML content (- for stdin)")
+	cmd.Flags().String("plain-file", "", "file with the email plain text content")
+	cmd.Flags().String("vars", "", "YAML or JSON file with template variables for the content")
+	cmd.MarkFlagsMutuallyExclusive("content", "content-file")
+}
+
+// emailContent returns the HTML and plain text content given by the content
+// flags, with the --vars variables filled in.
+func emailContent(c *cobra.Command) (html, plain string, err error) {
+	html, _ = c.Flags().GetString("content")
+	if path, _ := c.Flags().GetString("content-file"); path != "" {
+		if html, err = content.ReadFile(path); err != nil {
+			return "", "", err
+		}
+	}
+	if path, _ := c.Flags().GetString("plain-file"); path != "" {
+		if plain, err = content.ReadFile(path); err != nil {
+			return "", "", err
+		}
+	}
+
+	varsPath, _ := c.Flags().GetString("vars")
+	if varsPath == "" {
+		return html, plain, nil
+	}
+	if html == "" && plain == "" {
+		return "", "", fmt.Errorf("--vars needs --content, --content-file or --plain-file")
+	}
+	vars, err := content.LoadVars(varsPath)
+	if err != nil {
+		return "", "", err
+	}
+	if html, err = content.Render("content", html, vars); err != nil {
+		return "", "", fmt.Errorf("content: %w", err)
+	}
+	if plain, err = content.Render("plain text", plain, vars); err != nil {
+		return "", "", fmt.Errorf("plain text: %w", err)
+	}
+	return html, plain, nil
+}
+
+// --- preview ---
+
+var previewCmd = &cobra.Command{
+	Use:   "preview <campaign_id>",
+	Short: "Preview a campaign's content",
+	Long: `Show the stored content of a campaign's email as text in the terminal, or
+with --open save the HTML to a temporary file and open it in the browser.`,
+	Example: `  mailerlite campaign preview 123
+  mailerlite campaign preview 123 --open`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPreview,
+}
+
+// emailContents is the content of a campaign email, which the SDK does not
+// return.
+type emailContents struct {
+	Subject   string `json:"subject"`
+	FromName  string `json:"from_name"`
+	From      string `json:"from"`
+	Content   string `json:"content"`
+	PlainText string `json:"plain_text"`
+}
+
+// fetchEmail returns the content of a campaign's first email.
+func fetchEmail(c *cobra.Command, id string) (emailContents, error) {
+	httpClient, token, err := cmdutil.RawHTTPClient(c)
+	if err != nil {
+		return emailContents{}, err
+	}
+
+	var result struct {
+		Data struct {
+			Emails []emailContents `json:"emails"`
+		} `json:"data"`
+	}
+	if _, err := sdkclient.DoRaw(c.Context(), httpClient, token, http.MethodGet, "/campaigns/"+id, nil, &result); err != nil {
+		return emailContents{}, err
+	}
+	if len(result.Data.Emails) == 0 {
+		return emailContents{}, fmt.Errorf("campaign %s has no email", id)
+	}
+	return result.Data.Emails[0], nil
+}
+
+func runPreview(c *cobra.Command, args []string) error {
+	e, err := fetchEmail(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, e)
+	}
+	if e.Content == "" {
+		return fmt.Errorf("campaign %s has no HTML content", args[0])
+	}
+
+	if open, _ := c.Flags().GetBool("open"); open {
+		f, err := os.CreateTemp("", "mailerlite-campaign-"+args[0]+"-*.html")
+		if err != nil {
+			return err
+		}
+		if _, err := f.WriteString(e.Content); err != nil {
+			f.Close() //nolint:errcheck
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		cmdutil.OpenBrowser(f.Name())
+		output.Success("Opened " + f.Name() + " in the browser.")
+		return nil
+	}
+
+	fmt.Printf("Subject: %s\n\n", e.Subject)
+	fmt.Print(content.Text(e.Content))
+	return nil
+}
+
+// --- lint ---
+
+var lintCmd = &cobra.Command{
+	Use:   "lint <campaign_id>",
+	Short: "Check a campaign's content for common problems",
+	Long: `Check the content of a campaign's email before it is sent.
+
+Errors: no HTML content, no unsubscribe link, empty, relative or malformed
+links, and template tags left unresolved ({{...}}, or {$tag without its
+closing brace).
+
+Warnings: links that are not HTTPS, images without alt text, HTML larger than
+Gmail shows without clipping, merge tags that are not fields of the account,
+and no plain text version.
+
+The command fails if there are errors, and "campaign schedule" refuses to
+schedule such a campaign unless --force is given.`,
+	Example: `  mailerlite campaign lint 123
+  mailerlite campaign lint 123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLint,
+}
+
+func runLint(c *cobra.Command, args []string) error {
+	ml, err := cmdutil.NewSDKClient(c)
+	if err != nil {
+		return err
+	}
+
+	issues, err := lintCampaign(c, ml, args[0])
+	if err != nil {
+		return err
+	}
+	failed := countErrors(issues)
+
+	if cmdutil.StructuredOutput(c) {
+		if err := cmdutil.Print(c, issues); err != nil {
+			return err
+		}
+	} else if len(issues) > 0 {
+		rows := make([][]string, len(issues))
+		for i, issue := range issues {
+			rows[i] = []string{issue.Severity, issue.Rule, issue.Message}
+		}
+		output.Table([]string{"SEVERITY", "RULE", "MESSAGE"}, rows)
+		fmt.Println()
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("campaign %s failed lint with %d error(s) and %d warning(s)", args[0], failed, len(issues)-failed)
+	}
+	if !cmdutil.StructuredOutput(c) {
+		output.Success(fmt.Sprintf("Campaign %s passed lint with %d warning(s).", args[0], len(issues)))
+	}
+	return nil
+}
+
+// lintCampaign lints the content of a campaign's email against the fields
+// of the account.
+func lintCampaign(c *cobra.Command, ml *mailerlite.Client, id string) ([]content.Issue, error) {
+	e, err := fetchEmail(c, id)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := sdkclient.FetchAll(c.Context(), func(ctx context.Context, page, perPage int) ([]mailerlite.Field, bool, error) {
+		root, _, err := ml.Field.List(ctx, &mailerlite.ListFieldOptions{Page: page, Limit: perPage})
+		if err != nil {
+			return nil, false, sdkclient.WrapError(err)
+		}
+		return root.Data, !root.Links.IsLastPage(), nil
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fields: %w", err)
+	}
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = f.Key
+	}
+
+	issues := content.Lint(content.Email{Subject: e.Subject, HTML: e.Content, PlainText: e.PlainText}, keys)
+	if issues == nil {
+		issues = []content.Issue{}
+	}
+	return issues, nil
+}
+
+func countErrors(issues []content.Issue) int {
+	n := 0
+	for _, i := range issues {
+		if i.Severity == content.SeverityError {
+			n++
+		}
+	}
+	return n
+}
+
 // --- schedule ---
 
 var scheduleCmd = &cobra.Command{
 	Use:   "schedule <campaign_id>",
 	Short: "Schedule a campaign",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSchedule,
+	Long: `Send a campaign now or schedule it for later.
+
+Give the send time with --at, either as a date and time or in a relative
+form such as "tomorrow 9am", "next monday 14:00" or "in 2 hours", and its
+timezone by name with --tz (local time by default). The time is resolved to
+a MailerLite timezone, shown in both the target and the local timezone and,
+when interactive, confirmed. Times in the past are rejected. --date, --hours,
+--minutes and --timezone-id remain available for exact values.
+
+The campaign is checked with "campaign lint" first and is not scheduled if
+that finds errors, unless --force is given. Warnings are printed only.`,
+	Example: `  mailerlite campaign schedule 123
+  mailerlite campaign schedule 123 --at "2026-11-02 09:30" --tz Europe/Vilnius
+  mailerlite campaign schedule 123 --at "tomorrow 9am" --tz America/New_York`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSchedule,
 }
 
 func runSchedule(c *cobra.Command, args []string) error {
@@ -362,11 +673,21 @@ func runSchedule(c *cobra.Command, args []string) error {
 	}
 
 	delivery, _ := c.Flags().GetString("delivery")
+	at, _ := c.Flags().GetString("at")
+	tz, _ := c.Flags().GetString("tz")
+	if at != "" {
+		if c.Flags().Changed("delivery") && !strings.EqualFold(delivery, "scheduled") {
+			return fmt.Errorf("--at cannot be used with --delivery %s", delivery)
+		}
+		delivery = "scheduled"
+	}
 
 	opts := &mailerlite.ScheduleCampaign{
 		Delivery: delivery,
 	}
 
+	// sendTime describes the resolved --at time for confirmation.
+	var sendTime string
 	if strings.EqualFold(delivery, "scheduled") {
 		date, _ := c.Flags().GetString("date")
 		hours, _ := c.Flags().GetString("hours")
@@ -379,22 +700,146 @@ func runSchedule(c *cobra.Command, args []string) error {
 			Minutes:    minutes,
 			TimezoneID: timezoneID,
 		}
+		if at != "" {
+			if opts.Schedule, sendTime, err = atSchedule(c, ml, at, tz); err != nil {
+				return err
+			}
+		} else if tz != "" {
+			if opts.Schedule.TimezoneID, err = lookupTimezone(c, ml, tz); err != nil {
+				return err
+			}
+		}
+	}
+
+	if force, _ := c.Flags().GetBool("force"); !force {
+		issues, err := lintCampaign(c, ml, args[0])
+		if err != nil {
+			return err
+		}
+		for _, i := range issues {
+			output.Errorf("%s: %s", i.Severity, i.Message)
+		}
+		if n := countErrors(issues); n > 0 {
+			return fmt.Errorf("campaign %s failed lint with %d error(s); fix them or pass --force to schedule anyway", args[0], n)
+		}
+	}
+
+	if sendTime != "" {
+		msg := fmt.Sprintf("Campaign %s will be sent on %s.", args[0], sendTime)
+		if cmdutil.StructuredOutput(c) {
+			fmt.Fprintln(os.Stderr, msg) //nolint:errcheck
+		} else {
+			fmt.Println(msg)
+		}
+		if !cmdutil.YesFlag(c) && prompt.IsInteractive() {
+			ok, err := prompt.Confirm("Schedule it?")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+		}
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Campaign.Schedule(ctx, args[0], opts)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Campaign " + args[0] + " scheduled successfully.")
 	return nil
 }
 
+// atSchedule resolves --at and --tz to a schedule in a MailerLite timezone,
+// and describes the send time in both the target and the local timezone.
+// Without --tz, --at is in local time and the campaign is scheduled in the
+// local timezone.
+func atSchedule(c *cobra.Command, ml *mailerlite.Client, at, tz string) (*mailerlite.Schedule, string, error) {
+	now := time.Now()
+	in := time.Local
+	if tz != "" {
+		var err error
+		if in, err = time.LoadLocation(tz); err != nil {
+			return nil, "", fmt.Errorf("unknown timezone %q: use a name like Europe/Vilnius", tz)
+		}
+	} else {
+		tz = datetime.LocalZone()
+	}
+
+	t, err := datetime.Parse(at, now.In(in))
+	if err != nil {
+		return nil, "", err
+	}
+	if !t.After(now) {
+		return nil, "", fmt.Errorf("%s is in the past", t.Format("2006-01-02 15:04 MST"))
+	}
+
+	zoneID, zone, err := scheduleTimezone(c, ml, tz, t)
+	if err != nil {
+		return nil, "", err
+	}
+
+	schedule := &mailerlite.Schedule{
+		Date:       t.Format(time.DateOnly),
+		Hours:      t.Format("15"),
+		Minutes:    t.Format("04"),
+		TimezoneID: zoneID,
+	}
+	desc := fmt.Sprintf("%s (%s), %s local time",
+		t.Format("Mon 2006-01-02 15:04 MST"), zone, t.Local().Format("Mon 2006-01-02 15:04 MST"))
+	return schedule, desc, nil
+}
+
+// lookupTimezone returns the ID of the MailerLite timezone with the given name,
+// e.g. Europe/Vilnius.
+func lookupTimezone(c *cobra.Command, ml *mailerlite.Client, name string) (int, error) {
+	result, _, err := ml.Timezone.List(c.Context())
+	if err != nil {
+		return 0, sdkclient.WrapError(err)
+	}
+	for _, tz := range result.Data {
+		if strings.EqualFold(tz.Name, name) {
+			return strconv.Atoi(tz.Id)
+		}
+	}
+	return 0, fmt.Errorf("MailerLite has no timezone %q; see \"mailerlite timezone list\"", name)
+}
+
+// scheduleTimezone returns the ID and name of the MailerLite timezone in
+// which to schedule t: the one with the given name if MailerLite has it,
+// otherwise one with the same UTC offset as t at that time, so that t's
+// date and time of day mean the same instant there.
+func scheduleTimezone(c *cobra.Command, ml *mailerlite.Client, name string, t time.Time) (int, string, error) {
+	result, _, err := ml.Timezone.List(c.Context())
+	if err != nil {
+		return 0, "", sdkclient.WrapError(err)
+	}
+	for _, tz := range result.Data {
+		if name != "" && strings.EqualFold(tz.Name, name) {
+			id, err := strconv.Atoi(tz.Id)
+			return id, tz.Name, err
+		}
+	}
+	_, offset := t.Zone()
+	for _, tz := range result.Data {
+		loc, err := time.LoadLocation(tz.Name)
+		if err != nil {
+			continue
+		}
+		if _, o := t.In(loc).Zone(); o == offset {
+			id, err := strconv.Atoi(tz.Id)
+			return id, tz.Name, err
+		}
+	}
+	return 0, "", fmt.Errorf("MailerLite has no timezone at UTC%s on %s; see \"mailerlite timezone list\"", t.Format("-07:00"), t.Format(time.DateOnly))
+}
+
 // --- cancel ---
 
 var cancelCmd = &cobra.Command{
@@ -410,14 +855,14 @@ func runCancel(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Campaign.Cancel(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Campaign " + args[0] + " cancelled successfully.")
@@ -429,8 +874,29 @@ func runCancel(c *cobra.Command, args []string) error {
 var subscribersCmd = &cobra.Command{
 	Use:   "subscribers <campaign_id>",
 	Short: "List campaign subscriber activity",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSubscribers,
+	Long: `List the recipients of a campaign with their opens and clicks.
+
+--type keeps only the subscribers who opened, did not open, clicked,
+unsubscribed, forwarded, bounced (hard or soft) or marked the campaign as
+junk. With --to-group, the matching subscribers are added to a group instead
+of being listed, e.g. to retarget those who did not open.`,
+	Example: `  mailerlite campaign subscribers 123 --type clicked
+  mailerlite campaign subscribers 123 --type unopened --to-group 456`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSubscribers,
+}
+
+// activityTypes are the --type values and the report filters each stands for.
+var activityTypes = map[string][]string{
+	"opened":       {"opened"},
+	"unopened":     {"unopened"},
+	"clicked":      {"clicked"},
+	"unsubscribed": {"unsubscribed"},
+	"forwarded":    {"forwarded"},
+	"bounced":      {"hardbounced", "softbounced"},
+	"hardbounced":  {"hardbounced"},
+	"softbounced":  {"softbounced"},
+	"junk":         {"junk"},
 }
 
 func runSubscribers(c *cobra.Command, args []string) error {
@@ -440,45 +906,90 @@ func runSubscribers(c *cobra.Command, args []string) error {
 	}
 
 	limit, _ := c.Flags().GetInt("limit")
+	groupID, _ := c.Flags().GetString("to-group")
+	if groupID != "" && !c.Flags().Changed("limit") {
+		limit = 0
+	}
 
-	ctx := context.Background()
-
-	subscribers, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.CampaignSubscriber, bool, error) {
-		opts := &mailerlite.ListCampaignSubscriberOptions{
-			CampaignID: args[0],
-			Page:       page,
-			Limit:      perPage,
+	filters := []string{""}
+	if typ, _ := c.Flags().GetString("type"); typ != "" {
+		var ok bool
+		if filters, ok = activityTypes[typ]; !ok {
+			return fmt.Errorf("invalid --type %q: use one of %s", typ, strings.Join(slices.Sorted(maps.Keys(activityTypes)), ", "))
 		}
+	}
 
-		root, _, err := ml.Campaign.Subscribers(ctx, opts)
+	subscribers := campaignActivity(c, ml, args[0], filters, limit)
+	if groupID == "" {
+		return cmdutil.PrintList(c, columns.CampaignSubscriber, subscribers)
+	}
+
+	var ids []string
+	seen := map[string]bool{}
+	for s, err := range subscribers {
 		if err != nil {
-			return nil, false, sdkclient.WrapError(err)
+			return err
+		}
+		if !seen[s.Subscriber.ID] {
+			seen[s.Subscriber.ID] = true
+			ids = append(ids, s.Subscriber.ID)
 		}
-
-		return root.Data, !root.Links.IsLastPage(), nil
-	}, limit)
-	if err != nil {
-		return err
 	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(subscribers)
+	if len(ids) == 0 && !cmdutil.StructuredOutput(c) {
+		output.Success("No subscribers matched; group " + groupID + " is unchanged.")
+		return nil
 	}
 
-	headers := []string{"ID", "EMAIL", "OPENS", "CLICKS"}
-	var rows [][]string
-
-	for _, s := range subscribers {
-		rows = append(rows, []string{
-			s.ID,
-			s.Subscriber.Email,
-			strconv.Itoa(s.OpensCount),
-			strconv.Itoa(s.ClicksCount),
-		})
+	assign := func(ctx context.Context, id string) error {
+		_, _, err := ml.Group.Assign(ctx, groupID, id)
+		return sdkclient.WrapError(err)
 	}
+	assignRequest := func(_ context.Context, id string) (sdkclient.BatchRequest, error) {
+		return sdkclient.BatchRequest{Method: http.MethodPost, Path: "/subscribers/" + id + "/groups/" + groupID}, nil
+	}
+	summary, err := cmdutil.RunBulk(c, "Adding", ids, assign, assignRequest)
+	return cmdutil.PrintBulkSummary(c, summary, err,
+		fmt.Sprintf("Added %d of %d subscribers to group %s.", summary.Succeeded, summary.Total, groupID))
+}
 
-	output.Table(headers, rows)
-	return nil
+// campaignActivity iterates over the recipients of a campaign matching any
+// of the report filters ("" for all), up to limit (0 = all).
+func campaignActivity(c *cobra.Command, ml *mailerlite.Client, campaignID string, filters []string, limit int) iter.Seq2[mailerlite.CampaignSubscriber, error] {
+	return func(yield func(mailerlite.CampaignSubscriber, error) bool) {
+		n := 0
+		for _, filter := range filters {
+			remaining := 0
+			if limit > 0 {
+				remaining = limit - n
+			}
+			subscribers := sdkclient.Iterate(c.Context(), func(ctx context.Context, page, perPage int) ([]mailerlite.CampaignSubscriber, bool, error) {
+				opts := &mailerlite.ListCampaignSubscriberOptions{
+					CampaignID: campaignID,
+					Page:       page,
+					Limit:      perPage,
+				}
+				if filter != "" {
+					opts.Filters = &[]mailerlite.Filter{{Name: "type", Value: filter}}
+				}
+
+				root, _, err := ml.Campaign.Subscribers(ctx, opts)
+				if err != nil {
+					return nil, false, sdkclient.WrapError(err)
+				}
+
+				return root.Data, !root.Links.IsLastPage(), nil
+			}, cmdutil.PageOptions(c, remaining))
+
+			for s, err := range subscribers {
+				if !yield(s, err) || err != nil {
+					return
+				}
+				if n++; limit > 0 && n >= limit {
+					return
+				}
+			}
+		}
+	}
 }
 
 // --- languages ---
@@ -495,38 +1006,28 @@ func runLanguages(c *cobra.Command, _ []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Campaign.Languages(ctx)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result.Data)
-	}
-
-	headers := []string{"ID", "NAME", "SHORTCODE"}
-	var rows [][]string
-
-	for _, lang := range result.Data {
-		rows = append(rows, []string{
-			lang.Id,
-			lang.Name,
-			lang.Shortcode,
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.CampaignLanguage, output.Items(result.Data))
 }
 
 // --- delete ---
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete <campaign_id>",
-	Short: "Delete a campaign",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDelete,
+	Use:   "delete <campaign_id>...",
+	Short: "Delete campaigns",
+	Long: `Delete one or more campaigns.
+
+Pass many campaign IDs with --file, one per line, or --file - to read them
+from stdin.`,
+	Example: `  mailerlite campaign delete 123
+  mailerlite campaign list --status draft --limit 0 --columns id --no-headers -o tsv | mailerlite campaign delete --file - --yes`,
+	Args: cmdutil.BulkArgs(0),
+	RunE: runDelete,
 }
 
 func runDelete(c *cobra.Command, args []string) error {
@@ -535,8 +1036,18 @@ func runDelete(c *cobra.Command, args []string) error {
 		return err
 	}
 
+	items, err := cmdutil.BulkItems(c, args)
+	if err != nil {
+		return err
+	}
+	bulk := cmdutil.IsBulk(c, items)
+
 	if !cmdutil.YesFlag(c) && prompt.IsInteractive() {
-		ok, err := prompt.Confirm("Are you sure you want to delete campaign " + args[0] + "?")
+		label := "Are you sure you want to delete campaign " + items[0] + "?"
+		if bulk {
+			label = fmt.Sprintf("Are you sure you want to delete %d campaigns?", len(items))
+		}
+		ok, err := prompt.Confirm(label)
 		if err != nil {
 			return err
 		}
@@ -545,12 +1056,24 @@ func runDelete(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := context.Background()
-	_, err = ml.Campaign.Delete(ctx, args[0])
-	if err != nil {
+	del := func(ctx context.Context, id string) error {
+		_, err := ml.Campaign.Delete(ctx, id)
 		return sdkclient.WrapError(err)
 	}
 
-	output.Success("Campaign " + args[0] + " deleted successfully.")
+	if bulk {
+		delRequest := func(_ context.Context, id string) (sdkclient.BatchRequest, error) {
+			return sdkclient.BatchRequest{Method: http.MethodDelete, Path: "/campaigns/" + id}, nil
+		}
+		summary, err := cmdutil.RunBulk(c, "Deleting", items, del, delRequest)
+		return cmdutil.PrintBulkSummary(c, summary, err,
+			fmt.Sprintf("Deleted %d of %d campaigns.", summary.Succeeded, summary.Total))
+	}
+
+	if err := del(c.Context(), items[0]); err != nil {
+		return err
+	}
+
+	output.Success("Campaign " + items[0] + " deleted successfully.")
 	return nil
 }
@@ -0,0 +1,325 @@
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/datetime"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/prompt"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/spf13/cobra"
+)
+
+// --- duplicate ---
+
+var duplicateCmd = &cobra.Command{
+	Use:   "duplicate <campaign_id>",
+	Short: "Copy a campaign into a new draft",
+	Long: `Create a draft copy of a campaign with the same sender, subject, HTML and
+plain text content, groups and segments.
+
+A/B split and auto resend campaigns are copied as regular campaigns with
+their first email, as the API does not return their settings. Campaigns
+with recipient conditions other than "in any of these groups or segments",
+such as one excluding a group, cannot be duplicated.`,
+	Example: `  mailerlite campaign duplicate 123 --name "April newsletter"
+  mailerlite campaign duplicate 123 --name "April newsletter" --subject "What's new in April"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDuplicate,
+}
+
+func runDuplicate(c *cobra.Command, args []string) error {
+	ml, err := cmdutil.NewSDKClient(c)
+	if err != nil {
+		return err
+	}
+
+	original, _, err := ml.Campaign.Get(c.Context(), args[0])
+	if err != nil {
+		return sdkclient.WrapError(err)
+	}
+	d := original.Data
+
+	name, _ := c.Flags().GetString("name")
+	if name == "" {
+		name = d.Name + " (copy)"
+	}
+	subject, _ := c.Flags().GetString("subject")
+	groups, segments, err := campaignRecipients(d)
+	if err != nil {
+		return err
+	}
+	e, err := fetchEmail(c, d.ID)
+	if err != nil {
+		return err
+	}
+
+	result, err := copyCampaign(c, d, e, name, subject, groups, segments)
+	if err != nil {
+		return err
+	}
+
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
+	}
+
+	output.Success("Campaign " + args[0] + " duplicated. ID: " + result.Data.ID)
+	return nil
+}
+
+// campaignRecipients returns the group and segment IDs a campaign is sent to.
+// Only "in_any" conditions on groups and segments can be copied; any other
+// condition, such as one excluding a group, is an error rather than being
+// turned into a recipient list.
+func campaignRecipients(d mailerlite.Campaign) (groups, segments []string, err error) {
+	for _, conditions := range d.Filter {
+		for _, f := range conditions {
+			ids, ok := recipientIDs(f)
+			if !ok {
+				return nil, nil, fmt.Errorf("campaign %s has a recipient condition that cannot be copied: %s %v", d.ID, f.Operator, f.Args)
+			}
+			switch f.Args[0] {
+			case "groups":
+				groups = append(groups, ids...)
+			case "segments":
+				segments = append(segments, ids...)
+			}
+		}
+	}
+	return groups, segments, nil
+}
+
+// recipientIDs returns the IDs of an "in_any" condition on groups or
+// segments.
+func recipientIDs(f mailerlite.CampaignFilter) ([]string, bool) {
+	if f.Operator != "in_any" || len(f.Args) != 2 || (f.Args[0] != "groups" && f.Args[0] != "segments") {
+		return nil, false
+	}
+	list, ok := f.Args[1].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	ids := make([]string, len(list))
+	for i, id := range list {
+		ids[i] = fmt.Sprint(id)
+	}
+	return ids, true
+}
+
+// copyCampaign creates a draft with the first email of campaign d, whose
+// content is e, sent to the given groups and segments. An empty subject
+// keeps the original one.
+func copyCampaign(c *cobra.Command, d mailerlite.Campaign, e emailContents, name, subject string, groups, segments []string) (*mailerlite.RootCampaign, error) {
+	if subject == "" {
+		subject = e.Subject
+	}
+
+	campaignType := d.Type
+	if campaignType != "regular" {
+		output.Errorf("Campaign %s is a %s campaign; the copy is a regular campaign with its first email.", d.ID, d.TypeForHumans)
+		campaignType = "regular"
+	}
+	languageID, _ := strconv.Atoi(d.LanguageID)
+
+	body := campaignRequest{
+		CreateCampaign: mailerlite.CreateCampaign{
+			Name:       name,
+			LanguageID: languageID,
+			Type:       campaignType,
+			Groups:     groups,
+			Segments:   segments,
+		},
+		Emails: []email{
+			{
+				Emails: mailerlite.Emails{
+					Subject:  subject,
+					From:     e.From,
+					FromName: e.FromName,
+					Content:  e.Content,
+				},
+				PlainText: e.PlainText,
+			},
+		},
+	}
+	return saveCampaign(c, http.MethodPost, "/campaigns", body)
+}
+
+// --- resend ---
+
+var resendCmd = &cobra.Command{
+	Use:   "resend <campaign_id>",
+	Short: "Resend a sent campaign to the subscribers who did not open it",
+	Long: `Resend a sent campaign to the subscribers who did not open it.
+
+The non-openers are added to a new group, and a copy of the campaign sent to
+that group is created and scheduled --after the original was sent. If that
+time has already passed, the copy is left as a draft to be scheduled with
+"campaign schedule". Give the copy a different subject with --subject to
+improve its chance of being opened.
+
+Auto resend campaigns (campaign create --type resend) resend by themselves
+but must be set up before the original is sent; this command is for
+campaigns that were sent as regular campaigns.`,
+	Example: `  mailerlite campaign resend 123 --to non-openers --after 48h --subject "In case you missed it"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runResend,
+}
+
+// resendResult is the structured output of campaign resend.
+type resendResult struct {
+	CampaignID   string `json:"campaign_id"`
+	GroupID      string `json:"group_id"`
+	Subscribers  int    `json:"subscribers"`
+	Failed       int    `json:"failed"`
+	ScheduledFor string `json:"scheduled_for,omitempty"`
+}
+
+func runResend(c *cobra.Command, args []string) (err error) {
+	ml, err := cmdutil.NewSDKClient(c)
+	if err != nil {
+		return err
+	}
+
+	if to, _ := c.Flags().GetString("to"); to != "non-openers" {
+		return fmt.Errorf("invalid --to %q: only non-openers is supported", to)
+	}
+	afterValue, _ := c.Flags().GetString("after")
+	after, err := datetime.ParseDuration(afterValue)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Context()
+	original, _, err := ml.Campaign.Get(ctx, args[0])
+	if err != nil {
+		return sdkclient.WrapError(err)
+	}
+	d := original.Data
+	if d.Status != "sent" {
+		return fmt.Errorf("campaign %s has not been sent yet", d.ID)
+	}
+	sent, ok := sentAt(d)
+	if !ok {
+		return fmt.Errorf("campaign %s has no send time", d.ID)
+	}
+	// Fetch the content before the group is created, so that a campaign
+	// that cannot be copied does not leave a group behind.
+	e, err := fetchEmail(c, d.ID)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	seen := map[string]bool{}
+	for s, err := range campaignActivity(c, ml, d.ID, activityTypes["unopened"], 0) {
+		if err != nil {
+			return err
+		}
+		if !seen[s.Subscriber.ID] {
+			seen[s.Subscriber.ID] = true
+			ids = append(ids, s.Subscriber.ID)
+		}
+	}
+	if len(ids) == 0 {
+		output.Success("Every recipient of campaign " + d.ID + " opened it; there is nobody to resend to.")
+		return nil
+	}
+
+	if !cmdutil.YesFlag(c) && prompt.IsInteractive() {
+		ok, err := prompt.Confirm(fmt.Sprintf("Resend campaign %s to %d subscribers who did not open it?", d.ID, len(ids)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	name, _ := c.Flags().GetString("name")
+	if name == "" {
+		name = d.Name + " (resend)"
+	}
+	group, _, err := ml.Group.Create(ctx, name+" non-openers")
+	if err != nil {
+		return sdkclient.WrapError(err)
+	}
+	res := resendResult{GroupID: group.Data.ID}
+	// Undo a half-finished resend, even after Ctrl-C, so that a re-run does
+	// not find a stray group and draft.
+	defer func() {
+		if err == nil {
+			return
+		}
+		ctx := context.WithoutCancel(ctx)
+		if res.CampaignID != "" {
+			if _, delErr := ml.Campaign.Delete(ctx, res.CampaignID); delErr != nil {
+				output.Errorf("Failed to remove draft campaign %s: %s", res.CampaignID, sdkclient.WrapError(delErr))
+			}
+		}
+		if _, delErr := ml.Group.Delete(ctx, res.GroupID); delErr != nil {
+			output.Errorf("Failed to remove group %s: %s", res.GroupID, sdkclient.WrapError(delErr))
+		}
+	}()
+
+	assign := func(ctx context.Context, id string) error {
+		_, _, err := ml.Group.Assign(ctx, res.GroupID, id)
+		return sdkclient.WrapError(err)
+	}
+	assignRequest := func(_ context.Context, id string) (sdkclient.BatchRequest, error) {
+		return sdkclient.BatchRequest{Method: http.MethodPost, Path: "/subscribers/" + id + "/groups/" + res.GroupID}, nil
+	}
+	summary, err := cmdutil.RunBulk(c, "Adding", ids, assign, assignRequest)
+	if err != nil {
+		return err
+	}
+	for _, r := range summary.Failures() {
+		output.Errorf("%s: %s", r.Item, r.Error)
+	}
+	res.Subscribers, res.Failed = summary.Succeeded, summary.Failed
+
+	subject, _ := c.Flags().GetString("subject")
+	result, err := copyCampaign(c, d, e, name, subject, []string{res.GroupID}, nil)
+	if err != nil {
+		return err
+	}
+	res.CampaignID = result.Data.ID
+
+	at := time.Unix(sent, 0).Add(after)
+	if at.After(time.Now()) {
+		zoneID, _, err := scheduleTimezone(c, ml, datetime.LocalZone(), at)
+		if err != nil {
+			return err
+		}
+		_, _, err = ml.Campaign.Schedule(ctx, res.CampaignID, &mailerlite.ScheduleCampaign{
+			Delivery: "scheduled",
+			Schedule: &mailerlite.Schedule{
+				Date:       at.Format(time.DateOnly),
+				Hours:      at.Format("15"),
+				Minutes:    at.Format("04"),
+				TimezoneID: zoneID,
+			},
+		})
+		if err != nil {
+			return sdkclient.WrapError(err)
+		}
+		res.ScheduledFor = at.UTC().Format(time.DateTime)
+	}
+
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, res)
+	}
+
+	fmt.Printf("Added %d non-openers to group %s.\n", res.Subscribers, res.GroupID)
+	if res.ScheduledFor == "" {
+		output.Success(fmt.Sprintf("Created resend campaign %s as a draft; %s after the original has passed, so schedule it with \"mailerlite campaign schedule %s\".", res.CampaignID, afterValue, res.CampaignID))
+		return nil
+	}
+	output.Success(fmt.Sprintf("Created resend campaign %s, scheduled for %s.", res.CampaignID, at.Local().Format("2006-01-02 15:04 MST")))
+	return nil
+}
@@ -0,0 +1,244 @@
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/spf13/cobra"
+)
+
+// --- report ---
+
+var reportCmd = &cobra.Command{
+	Use:   "report [campaign_id]",
+	Short: "Show campaign engagement metrics",
+	Long: `Show the engagement of a sent campaign: opens, clicks, unsubscribes, spam
+complaints, hard and soft bounces and forwards, as counts and rates. A/B split
+campaigns are broken down by version side by side. The API does not report
+clicks per link, so links are not broken down.
+
+With --compare, tabulate every campaign sent in a date range instead, to spot
+trends. The range is given with --since and --until (YYYY-MM-DD or a unix
+timestamp) and defaults to the last 7 days.`,
+	Example: `  mailerlite campaign report 123
+  mailerlite campaign report --compare --since 2026-01-01
+  mailerlite campaign report --compare --since 2026-01-01 --until 2026-03-31 --columns name,stats.open_rate,stats.click_rate`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReport,
+}
+
+// reportMetrics are the rows of a campaign report.
+var reportMetrics = []struct {
+	name  string
+	value func(s mailerlite.Stats) string
+}{
+	{"Sent", func(s mailerlite.Stats) string { return strconv.Itoa(s.Sent) }},
+	{"Opens", func(s mailerlite.Stats) string { return countRate(s.UniqueOpensCount, s.OpenRate.String) }},
+	{"Clicks", func(s mailerlite.Stats) string { return countRate(s.UniqueClicksCount, s.ClickRate.String) }},
+	{"Click to open", func(s mailerlite.Stats) string { return s.ClickToOpenRate.String }},
+	{"Unsubscribes", func(s mailerlite.Stats) string { return countRate(s.UnsubscribesCount, s.UnsubscribeRate.String) }},
+	{"Spam complaints", func(s mailerlite.Stats) string { return countRate(s.SpamCount, s.SpamRate.String) }},
+	{"Hard bounces", func(s mailerlite.Stats) string { return countRate(s.HardBouncesCount, s.HardBounceRate.String) }},
+	{"Soft bounces", func(s mailerlite.Stats) string { return countRate(s.SoftBouncesCount, s.SoftBounceRate.String) }},
+	{"Forwards", func(s mailerlite.Stats) string { return strconv.Itoa(s.ForwardsCount) }},
+}
+
+func countRate(n int, rate string) string {
+	if rate == "" {
+		return strconv.Itoa(n)
+	}
+	return fmt.Sprintf("%d (%s)", n, rate)
+}
+
+// reportColumns are the default columns of a --compare report.
+var reportColumns = columns.Campaign.WithDefaults(
+	"id", "name", "finished_at", "stats.sent", "stats.open_rate", "stats.click_rate",
+	"stats.click_to_open_rate", "stats.unsubscribe_rate", "stats.spam_count",
+	"stats.hard_bounces_count", "stats.soft_bounces_count",
+)
+
+func runReport(c *cobra.Command, args []string) error {
+	compare, _ := c.Flags().GetBool("compare")
+	switch {
+	case compare && len(args) > 0:
+		return fmt.Errorf("--compare reports on all campaigns in a date range; leave out the campaign ID")
+	case !compare && len(args) == 0:
+		return fmt.Errorf("give a campaign ID, or --compare to report on all campaigns in a date range")
+	case !compare && (c.Flags().Changed("since") || c.Flags().Changed("until")):
+		return fmt.Errorf("--since and --until need --compare")
+	}
+
+	ml, err := cmdutil.NewSDKClient(c)
+	if err != nil {
+		return err
+	}
+	if compare {
+		return runCompareReport(c, ml)
+	}
+
+	result, _, err := ml.Campaign.Get(c.Context(), args[0])
+	if err != nil {
+		return sdkclient.WrapError(err)
+	}
+	d := result.Data
+
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, campaignReport(d))
+	}
+
+	fmt.Printf("Campaign:     %s (%s)\n", d.Name, d.ID)
+	fmt.Printf("Type:         %s\n", d.TypeForHumans)
+	fmt.Printf("Status:       %s\n", d.Status)
+	if d.FinishedAt != "" {
+		fmt.Printf("Sent At:      %s\n", d.FinishedAt)
+	}
+	if d.Status != "sent" {
+		output.Errorf("Campaign %s has not been sent yet; its statistics are empty.", d.ID)
+	}
+	fmt.Println()
+
+	headers := []string{"METRIC", "VALUE"}
+	stats := []mailerlite.Stats{d.Stats}
+	if d.Type == "ab" && len(d.Emails) > 1 {
+		headers = []string{"METRIC"}
+		stats = nil
+		for i, e := range d.Emails {
+			headers = append(headers, "VERSION "+string(rune('A'+i)))
+			stats = append(stats, e.Stats)
+		}
+		headers = append(headers, "TOTAL")
+		stats = append(stats, d.Stats)
+	}
+	rows := make([][]string, len(reportMetrics))
+	for i, m := range reportMetrics {
+		rows[i] = []string{m.name}
+		for _, s := range stats {
+			rows[i] = append(rows[i], m.value(s))
+		}
+	}
+	output.Table(headers, rows)
+
+	if d.Type == "ab" {
+		fmt.Println()
+		for i, e := range d.Emails {
+			fmt.Printf("Version %c:    %s\n", 'A'+i, e.Subject)
+		}
+		if winner, ok := d.WinnerVersionForHuman.(string); ok && winner != "" {
+			fmt.Printf("Winner:       %s\n", winner)
+		}
+	}
+	return nil
+}
+
+// report is the structured output of a single campaign report.
+type report struct {
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	Type       string           `json:"type"`
+	Status     string           `json:"status"`
+	FinishedAt string           `json:"finished_at"`
+	Stats      mailerlite.Stats `json:"stats"`
+	Versions   []versionReport  `json:"versions,omitempty"`
+	Winner     interface{}      `json:"winner,omitempty"`
+}
+
+type versionReport struct {
+	Version string           `json:"version"`
+	Subject string           `json:"subject"`
+	Stats   mailerlite.Stats `json:"stats"`
+}
+
+func campaignReport(d mailerlite.Campaign) report {
+	r := report{ID: d.ID, Name: d.Name, Type: d.Type, Status: d.Status, FinishedAt: d.FinishedAt, Stats: d.Stats}
+	if d.Type == "ab" {
+		for i, e := range d.Emails {
+			r.Versions = append(r.Versions, versionReport{Version: string(rune('A' + i)), Subject: e.Subject, Stats: e.Stats})
+		}
+		r.Winner = d.WinnerVersionForHuman
+	}
+	return r
+}
+
+// runCompareReport tabulates the campaigns sent in the --since/--until range,
+// oldest first.
+func runCompareReport(c *cobra.Command, ml *mailerlite.Client) error {
+	since, _ := c.Flags().GetString("since")
+	until, _ := c.Flags().GetString("until")
+	from, to, err := cmdutil.DefaultDateRange(since, until, time.Now())
+	if err != nil {
+		return err
+	}
+	// A date-only --until includes the whole day.
+	if _, err := time.Parse(time.DateOnly, until); err == nil {
+		to += int64(24*time.Hour/time.Second) - 1
+	}
+
+	var campaigns []mailerlite.Campaign
+	all := sdkclient.Iterate(c.Context(), func(ctx context.Context, page, perPage int) ([]mailerlite.Campaign, bool, error) {
+		root, _, err := ml.Campaign.List(ctx, &mailerlite.ListCampaignOptions{
+			Filters: &[]mailerlite.Filter{{Name: "status", Value: "sent"}},
+			Page:    page,
+			Limit:   perPage,
+		})
+		if err != nil {
+			return nil, false, sdkclient.WrapError(err)
+		}
+		return root.Data, !root.Links.IsLastPage(), nil
+	}, cmdutil.PageOptions(c, 0))
+	for campaign, err := range all {
+		if err != nil {
+			return err
+		}
+		if at, ok := sentAt(campaign); ok && at >= from && at <= to {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+	sort.SliceStable(campaigns, func(i, j int) bool {
+		a, _ := sentAt(campaigns[i])
+		b, _ := sentAt(campaigns[j])
+		return a < b
+	})
+
+	if err := cmdutil.PrintList(c, reportColumns, output.Items(campaigns)); err != nil {
+		return err
+	}
+	if cmdutil.StructuredOutput(c) || len(campaigns) == 0 {
+		return nil
+	}
+
+	var sent, opens, clicks, unsubscribes int
+	for _, campaign := range campaigns {
+		sent += campaign.Stats.Sent
+		opens += campaign.Stats.UniqueOpensCount
+		clicks += campaign.Stats.UniqueClicksCount
+		unsubscribes += campaign.Stats.UnsubscribesCount
+	}
+	fmt.Printf("\n%d campaigns, %d emails sent: %s opened, %s clicked, %s unsubscribed.\n",
+		len(campaigns), sent, percent(opens, sent), percent(clicks, sent), percent(unsubscribes, sent))
+	return nil
+}
+
+// sentAt returns when a campaign finished sending, as a unix timestamp.
+func sentAt(campaign mailerlite.Campaign) (int64, bool) {
+	for _, v := range []string{campaign.FinishedAt, campaign.StartedAt, campaign.ScheduledFor} {
+		if t, err := time.Parse(time.DateTime, v); err == nil {
+			return t.Unix(), true
+		}
+	}
+	return 0, false
+}
+
+func percent(n, total int) string {
+	if total == 0 {
+		return "0%"
+	}
+	return strconv.FormatFloat(float64(n)/float64(total)*100, 'f', 1, 64) + "%"
+}
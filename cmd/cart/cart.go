@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/ecommerce"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
@@ -57,9 +58,9 @@ var listCmd = &cobra.Command{
 		}
 
 		limit, _ := cmd.Flags().GetInt("limit")
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		carts, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.Cart, bool, error) {
+		carts := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.Cart, bool, error) {
 			path := fmt.Sprintf("/ecommerce/shops/%s/carts?page=%d&limit=%d", shopID, page, perPage)
 			var result ecommerce.RootCarts
 			_, err := sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -67,29 +68,9 @@ var listCmd = &cobra.Command{
 				return nil, false, err
 			}
 			return result.Data, !result.Links.IsLastPage(), nil
-		}, limit)
-		if err != nil {
-			return err
-		}
-
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(carts)
-		}
+		}, cmdutil.PageOptions(cmd, limit))
 
-		headers := []string{"ID", "CUSTOMER", "CURRENCY", "TOTAL", "CREATED"}
-		var rows [][]string
-		for _, c := range carts {
-			rows = append(rows, []string{
-				c.ID,
-				c.CustomerID,
-				c.Currency,
-				strconv.FormatFloat(c.Total, 'f', 2, 64),
-				c.CreatedAt,
-			})
-		}
-
-		output.Table(headers, rows)
-		return nil
+		return cmdutil.PrintList(cmd, columns.Cart, carts)
 	},
 }
 
@@ -109,7 +90,7 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/carts/%s", shopID, args[0])
 		var result ecommerce.RootCart
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -117,8 +98,8 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		c := result.Data
@@ -166,7 +147,7 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("no flags provided; use --help to see available options")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/carts/%s", shopID, args[0])
 		var result ecommerce.RootCart
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPut, path, body, &result)
@@ -174,8 +155,8 @@ var updateCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		output.Success(fmt.Sprintf("Cart updated: %s", result.Data.ID))
@@ -198,7 +179,7 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/carts?limit=0", shopID)
 		var result ecommerce.RootCount
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -206,8 +187,8 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result)
 		}
 
 		fmt.Printf("Total carts: %d\n", result.Total)
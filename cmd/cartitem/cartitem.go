@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/ecommerce"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
@@ -78,9 +79,9 @@ var listCmd = &cobra.Command{
 		}
 
 		limit, _ := cmd.Flags().GetInt("limit")
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		items, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.CartItem, bool, error) {
+		items := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.CartItem, bool, error) {
 			path := fmt.Sprintf("%s?page=%d&limit=%d", basePath(shopID, cartID), page, perPage)
 			var result ecommerce.RootCartItems
 			_, err := sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -88,29 +89,9 @@ var listCmd = &cobra.Command{
 				return nil, false, err
 			}
 			return result.Data, !result.Links.IsLastPage(), nil
-		}, limit)
-		if err != nil {
-			return err
-		}
-
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(items)
-		}
+		}, cmdutil.PageOptions(cmd, limit))
 
-		headers := []string{"ID", "PRODUCT", "QUANTITY", "PRICE", "CREATED"}
-		var rows [][]string
-		for _, i := range items {
-			rows = append(rows, []string{
-				i.ID,
-				i.ProductID,
-				strconv.Itoa(i.Quantity),
-				strconv.FormatFloat(i.Price, 'f', 2, 64),
-				i.CreatedAt,
-			})
-		}
-
-		output.Table(headers, rows)
-		return nil
+		return cmdutil.PrintList(cmd, columns.CartItem, items)
 	},
 }
 
@@ -134,7 +115,7 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("%s/%s", basePath(shopID, cartID), args[0])
 		var result ecommerce.RootCartItem
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -142,8 +123,8 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		i := result.Data
@@ -196,15 +177,15 @@ var createCmd = &cobra.Command{
 			"price":      price,
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		var result ecommerce.RootCartItem
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPost, basePath(shopID, cartID), body, &result)
 		if err != nil {
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		output.Success(fmt.Sprintf("Cart item created: %s (ID: %s)", result.Data.ProductID, result.Data.ID))
@@ -245,7 +226,7 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("no flags provided; use --help to see available options")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("%s/%s", basePath(shopID, cartID), args[0])
 		var result ecommerce.RootCartItem
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPut, path, body, &result)
@@ -253,8 +234,8 @@ var updateCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		output.Success(fmt.Sprintf("Cart item updated: %s", result.Data.ID))
@@ -282,7 +263,7 @@ var deleteCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("%s/%s", basePath(shopID, cartID), args[0])
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodDelete, path, nil, nil)
 		if err != nil {
@@ -313,7 +294,7 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("%s?limit=0", basePath(shopID, cartID))
 		var result ecommerce.RootCount
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -321,8 +302,8 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result)
 		}
 
 		fmt.Printf("Total cart items: %d\n", result.Total)
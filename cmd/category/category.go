@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/ecommerce"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
@@ -69,9 +70,9 @@ var listCmd = &cobra.Command{
 		}
 
 		limit, _ := cmd.Flags().GetInt("limit")
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		categories, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.Category, bool, error) {
+		categories := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.Category, bool, error) {
 			path := fmt.Sprintf("/ecommerce/shops/%s/categories?page=%d&limit=%d", shopID, page, perPage)
 			var result ecommerce.RootCategories
 			_, err := sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -79,23 +80,9 @@ var listCmd = &cobra.Command{
 				return nil, false, err
 			}
 			return result.Data, !result.Links.IsLastPage(), nil
-		}, limit)
-		if err != nil {
-			return err
-		}
-
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(categories)
-		}
+		}, cmdutil.PageOptions(cmd, limit))
 
-		headers := []string{"ID", "NAME", "CREATED"}
-		var rows [][]string
-		for _, c := range categories {
-			rows = append(rows, []string{c.ID, c.Name, c.CreatedAt})
-		}
-
-		output.Table(headers, rows)
-		return nil
+		return cmdutil.PrintList(cmd, columns.Category, categories)
 	},
 }
 
@@ -115,7 +102,7 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/categories/%s", shopID, args[0])
 		var result ecommerce.RootCategory
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -123,8 +110,8 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		c := result.Data
@@ -164,7 +151,7 @@ var createCmd = &cobra.Command{
 
 		body := map[string]string{"name": name}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/categories", shopID)
 		var result ecommerce.RootCategory
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPost, path, body, &result)
@@ -172,8 +159,8 @@ var createCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		output.Success(fmt.Sprintf("Category created: %s (ID: %s)", result.Data.Name, result.Data.ID))
@@ -206,7 +193,7 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("no flags provided; use --help to see available options")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/categories/%s", shopID, args[0])
 		var result ecommerce.RootCategory
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPut, path, body, &result)
@@ -214,8 +201,8 @@ var updateCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		output.Success(fmt.Sprintf("Category updated: %s (ID: %s)", result.Data.Name, result.Data.ID))
@@ -239,7 +226,7 @@ var deleteCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/categories/%s", shopID, args[0])
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodDelete, path, nil, nil)
 		if err != nil {
@@ -266,7 +253,7 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/categories?limit=0", shopID)
 		var result ecommerce.RootCount
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -274,8 +261,8 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result)
 		}
 
 		fmt.Printf("Total categories: %d\n", result.Total)
@@ -299,7 +286,7 @@ var productsCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/categories/%s/products", shopID, args[0])
 		var result ecommerce.RootProducts
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -307,8 +294,8 @@ var productsCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		headers := []string{"ID", "NAME", "PRICE", "CREATED"}
@@ -351,7 +338,7 @@ var assignProductCmd = &cobra.Command{
 
 		body := map[string]string{"product_id": productID}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/categories/%s/products", shopID, args[0])
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPost, path, body, nil)
 		if err != nil {
@@ -385,7 +372,7 @@ var unassignProductCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/categories/%s/products/%s", shopID, args[0], productID)
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodDelete, path, nil, nil)
 		if err != nil {
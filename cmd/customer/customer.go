@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/ecommerce"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
@@ -64,9 +65,9 @@ var listCmd = &cobra.Command{
 		}
 
 		limit, _ := cmd.Flags().GetInt("limit")
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		customers, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.Customer, bool, error) {
+		customers := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.Customer, bool, error) {
 			path := fmt.Sprintf("/ecommerce/shops/%s/customers?page=%d&limit=%d", shopID, page, perPage)
 			var result ecommerce.RootCustomers
 			_, err := sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -74,23 +75,9 @@ var listCmd = &cobra.Command{
 				return nil, false, err
 			}
 			return result.Data, !result.Links.IsLastPage(), nil
-		}, limit)
-		if err != nil {
-			return err
-		}
-
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(customers)
-		}
+		}, cmdutil.PageOptions(cmd, limit))
 
-		headers := []string{"ID", "EMAIL", "FIRST NAME", "LAST NAME", "CREATED"}
-		var rows [][]string
-		for _, c := range customers {
-			rows = append(rows, []string{c.ID, c.Email, c.FirstName, c.LastName, c.CreatedAt})
-		}
-
-		output.Table(headers, rows)
-		return nil
+		return cmdutil.PrintList(cmd, columns.Customer, customers)
 	},
 }
 
@@ -110,7 +97,7 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/customers/%s", shopID, args[0])
 		var result ecommerce.RootCustomer
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -118,8 +105,8 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		c := result.Data
@@ -167,7 +154,7 @@ var createCmd = &cobra.Command{
 			body["last_name"] = v
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/customers", shopID)
 		var result ecommerce.RootCustomer
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPost, path, body, &result)
@@ -175,8 +162,8 @@ var createCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		output.Success(fmt.Sprintf("Customer created: %s (ID: %s)", result.Data.Email, result.Data.ID))
@@ -217,7 +204,7 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("no flags provided; use --help to see available options")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/customers/%s", shopID, args[0])
 		var result ecommerce.RootCustomer
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPut, path, body, &result)
@@ -225,8 +212,8 @@ var updateCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		output.Success(fmt.Sprintf("Customer updated: %s (ID: %s)", result.Data.Email, result.Data.ID))
@@ -250,7 +237,7 @@ var deleteCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/customers/%s", shopID, args[0])
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodDelete, path, nil, nil)
 		if err != nil {
@@ -277,7 +264,7 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/customers?limit=0", shopID)
 		var result ecommerce.RootCount
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -285,8 +272,8 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result)
 		}
 
 		fmt.Printf("Total customers: %d\n", result.Total)
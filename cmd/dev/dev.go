@@ -0,0 +1,85 @@
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/mockapi"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Tools for developing against MailerLite",
+	Long:  "Tools for developing and testing against the MailerLite API without a real account.",
+}
+
+func init() {
+	Cmd.AddCommand(mockServerCmd)
+
+	// mock-server flags
+	mockServerCmd.Flags().String("addr", "127.0.0.1:8025", "address to listen on")
+	mockServerCmd.Flags().String("token", "", "only accept this API token (default: accept any token)")
+	mockServerCmd.Flags().Int("rate-limit", mockapi.DefaultRateLimit, "requests per minute before answering 429 (0 disables)")
+	mockServerCmd.Flags().Int("subscribers", 150, "number of sample subscribers to seed")
+}
+
+// --- mock-server ---
+
+var mockServerCmd = &cobra.Command{
+	Use:   "mock-server",
+	Short: "Run a local mock MailerLite API",
+	Long: `Run an in-memory imitation of the MailerLite API seeded with sample data.
+
+The mock serves subscribers, groups, fields, segments, forms, campaigns,
+automations, webhooks, timezones and e-commerce shops. It paginates like the
+real API, answers invalid input with validation errors and enforces a rate
+limit. Data is lost when the server stops.
+
+Point the CLI at it with MAILERLITE_API_BASE_URL:
+
+  $ mailerlite dev mock-server &
+  $ export MAILERLITE_API_BASE_URL=http://127.0.0.1:8025/api MAILERLITE_API_TOKEN=test
+  $ mailerlite group list`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{cmdutil.AnnotationRunsUntilStopped: ""},
+	RunE:        runMockServer,
+}
+
+func runMockServer(c *cobra.Command, args []string) error {
+	addr, _ := c.Flags().GetString("addr")
+	token, _ := c.Flags().GetString("token")
+	rateLimit, _ := c.Flags().GetInt("rate-limit")
+	subscribers, _ := c.Flags().GetInt("subscribers")
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	srv := mockapi.New(mockapi.Options{Token: token, RateLimit: rateLimit, Subscribers: subscribers})
+	baseURL := "http://" + ln.Addr().String() + "/api"
+	fmt.Fprintf(os.Stderr, "Mock MailerLite API listening on %s\n", baseURL)
+	fmt.Fprintf(os.Stderr, "  export MAILERLITE_API_BASE_URL=%s\n", baseURL)
+	if token == "" {
+		token = "test"
+	}
+	fmt.Fprintf(os.Stderr, "  export MAILERLITE_API_TOKEN=%s\n", token)
+
+	// Stop cleanly on Ctrl-C or when --timeout expires.
+	server := &http.Server{Handler: srv}
+	stop := context.AfterFunc(c.Context(), func() {
+		server.Shutdown(context.Background()) //nolint:errcheck // best-effort shutdown
+	})
+	defer stop()
+
+	if err := server.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
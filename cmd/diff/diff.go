@@ -0,0 +1,162 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mailerlite/mailerlite-cli/internal/backup"
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/diff"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare an account with another account or a backup",
+	Long: `Compare the fields, groups, segments, webhooks and forms of the selected
+profile's account with those of another profile's account, or of a snapshot
+made with "backup create".
+
+Items are matched by name. Items only in the other account or snapshot are
+shown as added (+), items only in this account as removed (-), and items in
+both whose properties differ as changed (~): a field's type, a webhook's URL,
+events and enabled state, and a form's type.
+
+With --exit-code, the command fails when there are differences, which makes
+it usable as a drift check in scripts.`,
+	Example: `  mailerlite diff --profile staging --against production
+  mailerlite diff --against mailerlite-backup-20260301-120000
+  mailerlite diff --against production --resources fields,webhooks --json`,
+	Args: cobra.NoArgs,
+	RunE: runDiff,
+}
+
+func init() {
+	Cmd.Flags().String("against", "", "profile or backup (directory or .tar.gz) to compare with (required)")
+	Cmd.Flags().StringSlice("resources", nil, "kinds of items to compare (default "+strings.Join(diff.Kinds, ",")+")")
+	Cmd.Flags().Bool("exit-code", false, "exit with an error if there are differences")
+}
+
+// lister returns the items of one kind on one side, or ok=false if that side
+// does not have them.
+type lister func(kind string) (items []json.RawMessage, ok bool, err error)
+
+func runDiff(c *cobra.Command, _ []string) error {
+	against, _ := c.Flags().GetString("against")
+	against, err := prompt.RequireArg(against, "against", "Profile or backup to compare with")
+	if err != nil {
+		return err
+	}
+	kinds, _ := c.Flags().GetStringSlice("resources")
+	for _, k := range kinds {
+		if !slices.Contains(diff.Kinds, k) {
+			return fmt.Errorf("unknown resource %q: use one of %s", k, strings.Join(diff.Kinds, ", "))
+		}
+	}
+	if len(kinds) == 0 {
+		kinds = diff.Kinds
+	}
+
+	base, err := accountLister(c, cmdutil.ProfileFlag(c))
+	if err != nil {
+		return err
+	}
+	var other lister
+	if _, statErr := os.Stat(against); statErr == nil {
+		snap, err := backup.Open(against)
+		if err != nil {
+			return err
+		}
+		defer snap.Close()
+		other = func(kind string) ([]json.RawMessage, bool, error) {
+			if !snap.Has(kind) {
+				return nil, false, nil
+			}
+			var items []json.RawMessage
+			err := snap.Read(kind, &items)
+			return items, true, err
+		}
+	} else if other, err = accountLister(c, against); err != nil {
+		return fmt.Errorf("%s is neither a backup nor a usable profile: %w", against, err)
+	}
+
+	changes := []diff.Change{}
+	var missing []string
+	for _, kind := range kinds {
+		theirs, ok, err := other(kind)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			missing = append(missing, kind)
+			continue
+		}
+		ours, _, err := base(kind)
+		if err != nil {
+			return err
+		}
+		kindChanges, err := diff.Compare(kind, ours, theirs)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, kindChanges...)
+	}
+	for _, kind := range missing {
+		output.Errorf("%s are not in %s and were not compared.", kind, against)
+	}
+
+	if cmdutil.StructuredOutput(c) {
+		if err := cmdutil.Print(c, changes); err != nil {
+			return err
+		}
+	} else {
+		printChanges(changes)
+	}
+
+	if exitCode, _ := c.Flags().GetBool("exit-code"); exitCode && len(changes) > 0 {
+		return fmt.Errorf("%d difference(s) found", len(changes))
+	}
+	return nil
+}
+
+// accountLister lists items from a profile's account.
+func accountLister(c *cobra.Command, profile string) (lister, error) {
+	httpClient, token, err := cmdutil.RawHTTPClientForProfile(c, profile)
+	if err != nil {
+		return nil, err
+	}
+	return func(kind string) ([]json.RawMessage, bool, error) {
+		items, err := backup.Fetch(c.Context(), httpClient, token, kind)
+		return items, true, err
+	}, nil
+}
+
+var changeSymbols = map[string]string{
+	diff.Added:   "+",
+	diff.Removed: "-",
+	diff.Changed: "~",
+}
+
+func printChanges(changes []diff.Change) {
+	if len(changes) == 0 {
+		fmt.Println("No differences.")
+		return
+	}
+	kind := ""
+	for _, ch := range changes {
+		if ch.Kind != kind {
+			kind = ch.Kind
+			fmt.Println(kind + ":")
+		}
+		fmt.Printf("  %s %q\n", changeSymbols[ch.Change], ch.Name)
+		for _, p := range ch.Properties {
+			fmt.Printf("      %s: %q -> %q\n", p.Name, p.From, p.To)
+		}
+	}
+	fmt.Printf("%d difference(s).\n", len(changes))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
@@ -53,9 +54,9 @@ func runList(c *cobra.Command, args []string) error {
 	limit, _ := c.Flags().GetInt("limit")
 	sort, _ := c.Flags().GetString("sort")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	allFields, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Field, bool, error) {
+	allFields := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Field, bool, error) {
 		opts := &mailerlite.ListFieldOptions{
 			Page:  page,
 			Limit: perPage,
@@ -66,29 +67,9 @@ func runList(c *cobra.Command, args []string) error {
 			return nil, false, sdkclient.WrapError(err)
 		}
 		return root.Data, !root.Links.IsLastPage(), nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(allFields)
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	headers := []string{"ID", "NAME", "KEY", "TYPE"}
-	var rows [][]string
-
-	for _, f := range allFields {
-		rows = append(rows, []string{
-			f.Id,
-			f.Name,
-			f.Key,
-			f.Type,
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Field, allFields)
 }
 
 // --- create ---
@@ -117,14 +98,14 @@ func runCreate(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Field.Create(ctx, name, fieldType)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Field created successfully. ID: " + result.Data.Id)
@@ -152,14 +133,14 @@ func runUpdate(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Field.Update(ctx, args[0], name)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Field " + args[0] + " updated successfully.")
@@ -191,7 +172,7 @@ func runDelete(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	_, err = ml.Field.Delete(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
@@ -3,9 +3,9 @@ package form
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
@@ -56,9 +56,9 @@ func runList(c *cobra.Command, _ []string) error {
 	formType, _ := c.Flags().GetString("type")
 	sort, _ := c.Flags().GetString("sort")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	forms, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Form, bool, error) {
+	forms := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Form, bool, error) {
 		opts := &mailerlite.ListFormOptions{
 			Type:  formType,
 			Page:  page,
@@ -72,35 +72,9 @@ func runList(c *cobra.Command, _ []string) error {
 		}
 
 		return root.Data, !root.Links.IsLastPage(), nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(forms)
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	headers := []string{"ID", "NAME", "TYPE", "ACTIVE", "CONVERSIONS", "OPENS"}
-	var rows [][]string
-
-	for _, f := range forms {
-		active := "No"
-		if f.Active {
-			active = "Yes"
-		}
-		rows = append(rows, []string{
-			f.Id,
-			output.Truncate(f.Name, 40),
-			f.Type,
-			active,
-			strconv.Itoa(f.ConversionsCount),
-			strconv.Itoa(f.OpensCount),
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Form, forms)
 }
 
 // --- get ---
@@ -118,14 +92,14 @@ func runGet(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Form.Get(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	d := result.Data
@@ -167,14 +141,14 @@ func runUpdate(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Form.Update(ctx, args[0], name)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Form " + args[0] + " updated successfully.")
@@ -206,7 +180,7 @@ func runDelete(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	_, err = ml.Form.Delete(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
@@ -233,9 +207,9 @@ func runSubscribers(c *cobra.Command, args []string) error {
 
 	limit, _ := c.Flags().GetInt("limit")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	subscribers, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Subscriber, bool, error) {
+	subscribers := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Subscriber, bool, error) {
 		opts := &mailerlite.ListFormSubscriberOptions{
 			FormID: args[0],
 			Page:   page,
@@ -248,27 +222,7 @@ func runSubscribers(c *cobra.Command, args []string) error {
 		}
 
 		return root.Data, !root.Links.IsLastPage(), nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(subscribers)
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	headers := []string{"ID", "EMAIL", "STATUS", "CREATED AT"}
-	var rows [][]string
-
-	for _, s := range subscribers {
-		rows = append(rows, []string{
-			s.ID,
-			s.Email,
-			s.Status,
-			s.CreatedAt,
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Subscriber.WithDefaults("id", "email", "status", "created_at"), subscribers)
 }
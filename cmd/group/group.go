@@ -3,9 +3,10 @@ package group
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"net/http"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
@@ -40,6 +41,12 @@ func init() {
 
 	// subscribers flags
 	subscribersCmd.Flags().Int("limit", 25, "maximum number of subscribers to return (0 = all)")
+
+	// assign flags
+	cmdutil.AddBulkFlags(assignCmd, "subscribers")
+
+	// unassign flags
+	cmdutil.AddBulkFlags(unassignCmd, "subscribers")
 }
 
 // --- list ---
@@ -59,9 +66,9 @@ func runList(c *cobra.Command, args []string) error {
 	limit, _ := c.Flags().GetInt("limit")
 	sort, _ := c.Flags().GetString("sort")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	groups, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Group, bool, error) {
+	groups := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Group, bool, error) {
 		opts := &mailerlite.ListGroupOptions{
 			Page:  page,
 			Limit: perPage,
@@ -75,32 +82,9 @@ func runList(c *cobra.Command, args []string) error {
 
 		hasNext := !root.Links.IsLastPage()
 		return root.Data, hasNext, nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(groups)
-	}
-
-	headers := []string{"ID", "NAME", "ACTIVE", "SENT", "OPENS", "CLICK RATE", "CREATED AT"}
-	var rows [][]string
-
-	for _, g := range groups {
-		rows = append(rows, []string{
-			g.ID,
-			output.Truncate(g.Name, 40),
-			strconv.Itoa(g.ActiveCount),
-			strconv.Itoa(g.SentCount),
-			strconv.Itoa(g.OpensCount),
-			g.ClickRate.String,
-			g.CreatedAt,
-		})
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Group, groups)
 }
 
 // --- create ---
@@ -123,14 +107,14 @@ func runCreate(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Group.Create(ctx, name)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Group created successfully. ID: " + result.Data.ID)
@@ -158,14 +142,14 @@ func runUpdate(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Group.Update(ctx, args[0], name)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Group " + args[0] + " updated successfully.")
@@ -197,7 +181,7 @@ func runDelete(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	_, err = ml.Group.Delete(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
@@ -225,9 +209,9 @@ func runSubscribers(c *cobra.Command, args []string) error {
 	limit, _ := c.Flags().GetInt("limit")
 	groupID := args[0]
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	subscribers, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Subscriber, bool, error) {
+	subscribers := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Subscriber, bool, error) {
 		opts := &mailerlite.ListGroupSubscriberOptions{
 			GroupID: groupID,
 			Page:    page,
@@ -241,40 +225,25 @@ func runSubscribers(c *cobra.Command, args []string) error {
 
 		hasNext := !root.Links.IsLastPage()
 		return root.Data, hasNext, nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(subscribers)
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	headers := []string{"EMAIL", "STATUS", "SOURCE", "OPENS", "CLICKS", "SUBSCRIBED AT"}
-	var rows [][]string
-
-	for _, s := range subscribers {
-		rows = append(rows, []string{
-			output.Truncate(s.Email, 40),
-			s.Status,
-			s.Source,
-			strconv.Itoa(s.OpensCount),
-			strconv.Itoa(s.ClicksCount),
-			s.SubscribedAt,
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Subscriber, subscribers)
 }
 
 // --- assign ---
 
 var assignCmd = &cobra.Command{
-	Use:   "assign <group_id> <subscriber_id>",
-	Short: "Assign a subscriber to a group",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runAssign,
+	Use:   "assign <group_id> <subscriber>...",
+	Short: "Assign subscribers to a group",
+	Long: `Assign one or more subscribers, given by ID or email address, to a group.
+
+Pass many subscribers with --file, one per line, or --file - to read them
+from stdin. They are assigned --concurrency at a time, and a summary of
+failures is printed at the end.`,
+	Example: `  mailerlite group assign 123 456
+  mailerlite group assign 123 --file subscribers.txt --concurrency 8`,
+	Args: cmdutil.BulkArgs(1),
+	RunE: runAssign,
 }
 
 func runAssign(c *cobra.Command, args []string) error {
@@ -283,23 +252,52 @@ func runAssign(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
-	_, _, err = ml.Group.Assign(ctx, args[0], args[1])
+	groupID := args[0]
+	items, err := cmdutil.BulkItems(c, args[1:])
 	if err != nil {
+		return err
+	}
+
+	assign := func(ctx context.Context, item string) error {
+		id, err := sdkclient.SubscriberID(ctx, ml, item)
+		if err != nil {
+			return err
+		}
+		_, _, err = ml.Group.Assign(ctx, groupID, id)
 		return sdkclient.WrapError(err)
 	}
 
-	output.Success(fmt.Sprintf("Subscriber %s assigned to group %s successfully.", args[1], args[0]))
-	return nil
+	if !cmdutil.IsBulk(c, items) {
+		if err := assign(c.Context(), items[0]); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("Subscriber %s assigned to group %s successfully.", items[0], groupID))
+		return nil
+	}
+
+	assignRequest := func(ctx context.Context, item string) (sdkclient.BatchRequest, error) {
+		id, err := sdkclient.SubscriberID(ctx, ml, item)
+		return sdkclient.BatchRequest{Method: http.MethodPost, Path: "/subscribers/" + id + "/groups/" + groupID}, err
+	}
+
+	summary, err := cmdutil.RunBulk(c, "Assigning", items, assign, assignRequest)
+	return cmdutil.PrintBulkSummary(c, summary, err,
+		fmt.Sprintf("Assigned %d of %d subscribers to group %s.", summary.Succeeded, summary.Total, groupID))
 }
 
 // --- unassign ---
 
 var unassignCmd = &cobra.Command{
-	Use:   "unassign <group_id> <subscriber_id>",
-	Short: "Unassign a subscriber from a group",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runUnassign,
+	Use:   "unassign <group_id> <subscriber>...",
+	Short: "Unassign subscribers from a group",
+	Long: `Unassign one or more subscribers, given by ID or email address, from a group.
+
+Pass many subscribers with --file, one per line, or --file - to read them
+from stdin.`,
+	Example: `  mailerlite group unassign 123 456
+  mailerlite subscriber list --status unsubscribed --limit 0 --columns id --no-headers -o tsv | mailerlite group unassign 123 --file -`,
+	Args: cmdutil.BulkArgs(1),
+	RunE: runUnassign,
 }
 
 func runUnassign(c *cobra.Command, args []string) error {
@@ -308,12 +306,35 @@ func runUnassign(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
-	_, err = ml.Group.UnAssign(ctx, args[0], args[1])
+	groupID := args[0]
+	items, err := cmdutil.BulkItems(c, args[1:])
 	if err != nil {
+		return err
+	}
+
+	unassign := func(ctx context.Context, item string) error {
+		id, err := sdkclient.SubscriberID(ctx, ml, item)
+		if err != nil {
+			return err
+		}
+		_, err = ml.Group.UnAssign(ctx, groupID, id)
 		return sdkclient.WrapError(err)
 	}
 
-	output.Success(fmt.Sprintf("Subscriber %s unassigned from group %s successfully.", args[1], args[0]))
-	return nil
+	if !cmdutil.IsBulk(c, items) {
+		if err := unassign(c.Context(), items[0]); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("Subscriber %s unassigned from group %s successfully.", items[0], groupID))
+		return nil
+	}
+
+	unassignRequest := func(ctx context.Context, item string) (sdkclient.BatchRequest, error) {
+		id, err := sdkclient.SubscriberID(ctx, ml, item)
+		return sdkclient.BatchRequest{Method: http.MethodDelete, Path: "/subscribers/" + id + "/groups/" + groupID}, err
+	}
+
+	summary, err := cmdutil.RunBulk(c, "Unassigning", items, unassign, unassignRequest)
+	return cmdutil.PrintBulkSummary(c, summary, err,
+		fmt.Sprintf("Unassigned %d of %d subscribers from group %s.", summary.Succeeded, summary.Total, groupID))
 }
@@ -1,7 +1,6 @@
 package importcmd
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -75,7 +74,7 @@ func doImport(cmd *cobra.Command, resource string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := cmd.Context()
 	path := fmt.Sprintf("/ecommerce/shops/%s/%s/import", shopID, resource)
 
 	var result json.RawMessage
@@ -84,8 +83,8 @@ func doImport(cmd *cobra.Command, resource string) error {
 		return err
 	}
 
-	if cmdutil.JSONFlag(cmd) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(cmd) {
+		return cmdutil.Print(cmd, result)
 	}
 
 	output.Success(fmt.Sprintf("Import of %s completed successfully.", resource))
@@ -0,0 +1,175 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mailerlite/mailerlite-cli/internal/bulk"
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/migrate"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy fields, groups and subscribers to another account",
+	Long: `Copy custom fields, groups and subscribers from the account of one profile to
+the account of another.
+
+Fields and groups missing in the target account are created, and both are
+matched by name to map field keys and group IDs between the accounts.
+Subscribers are then created or updated in the target with their status,
+field values and group memberships.
+
+Progress is tracked in a state file (migrate-<from>-<to>.state by default).
+If the migration is interrupted, re-run the same command with --resume to
+continue from the last completed page; subscribers that failed are retried.
+The state file is removed once every subscriber has been migrated.`,
+	Example: `  mailerlite migrate --from client-old --to client-new
+  mailerlite migrate --from client-old --to client-new --resources fields,groups
+  mailerlite migrate --from client-old --to client-new --resume`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+func init() {
+	Cmd.Flags().String("from", "", "profile of the source account (required)")
+	Cmd.Flags().String("to", "", "profile of the target account (required)")
+	Cmd.Flags().StringSlice("resources", nil, "resources to migrate (default "+strings.Join(migrate.Resources, ",")+")")
+	Cmd.Flags().String("state", "", "state file (default migrate-<from>-<to>.state)")
+	Cmd.Flags().Bool("resume", false, "continue an interrupted migration")
+	Cmd.Flags().Int("concurrency", bulk.DefaultConcurrency, "number of subscribers to migrate at once")
+}
+
+func runMigrate(c *cobra.Command, _ []string) error {
+	from, _ := c.Flags().GetString("from")
+	from, err := prompt.RequireArg(from, "from", "Source profile")
+	if err != nil {
+		return err
+	}
+	to, _ := c.Flags().GetString("to")
+	to, err = prompt.RequireArg(to, "to", "Target profile")
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return fmt.Errorf("--from and --to must be different profiles")
+	}
+
+	resources, _ := c.Flags().GetStringSlice("resources")
+	for _, r := range resources {
+		if !slices.Contains(migrate.Resources, r) {
+			return fmt.Errorf("unknown resource %q: use one of %s", r, strings.Join(migrate.Resources, ", "))
+		}
+	}
+	if len(resources) == 0 {
+		resources = migrate.Resources
+	}
+	concurrency, _ := c.Flags().GetInt("concurrency")
+
+	statePath, _ := c.Flags().GetString("state")
+	if statePath == "" {
+		statePath = fmt.Sprintf("migrate-%s-%s.state", from, to)
+	}
+	state := &migrate.State{From: from, To: to}
+	if resume, _ := c.Flags().GetBool("resume"); resume {
+		if state, err = migrate.ReadState(statePath); err != nil {
+			return err
+		}
+		if state.From != from || state.To != to {
+			return fmt.Errorf("%s is a migration from %s to %s", statePath, state.From, state.To)
+		}
+	} else if _, err := os.Stat(statePath); err == nil {
+		return fmt.Errorf("%s exists from an earlier migration; re-run with --resume to continue it, or remove it", statePath)
+	}
+
+	source, err := account(c, from)
+	if err != nil {
+		return fmt.Errorf("profile %s: %w", from, err)
+	}
+	target, err := account(c, to)
+	if err != nil {
+		return fmt.Errorf("profile %s: %w", to, err)
+	}
+
+	if !cmdutil.YesFlag(c) && prompt.IsInteractive() {
+		ok, err := prompt.Confirm(fmt.Sprintf("Migrate %s from %s to %s?", strings.Join(resources, ", "), from, to))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	opts := migrate.Options{Resources: resources, Concurrency: concurrency, Progress: cmdutil.ProgressWriter(c)}
+	summary, runErr := migrate.Run(c.Context(), source, target, state, statePath, opts)
+
+	if runErr == nil && len(summary.Failed) == 0 {
+		if err := os.Remove(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", statePath, err)
+		}
+	}
+
+	if cmdutil.StructuredOutput(c) {
+		if err := cmdutil.Print(c, summary); err != nil {
+			return err
+		}
+	} else {
+		printSummary(summary, resources)
+	}
+
+	if runErr != nil {
+		if c.Context().Err() != nil {
+			output.Errorf("Migration stopped after %d subscribers; re-run with --resume to continue.", summary.Migrated)
+		}
+		return runErr
+	}
+	if len(summary.Failed) > 0 {
+		return fmt.Errorf("%d subscribers failed; re-run with --resume to retry them", len(summary.Failed))
+	}
+	return nil
+}
+
+// account returns the clients for a profile's account.
+func account(c *cobra.Command, profile string) (migrate.Account, error) {
+	ml, err := cmdutil.NewSDKClientForProfile(c, profile)
+	if err != nil {
+		return migrate.Account{}, err
+	}
+	httpClient, token, err := cmdutil.RawHTTPClientForProfile(c, profile)
+	if err != nil {
+		return migrate.Account{}, err
+	}
+	return migrate.Account{SDK: ml, HTTP: httpClient, APIKey: token}, nil
+}
+
+func printSummary(s *migrate.Summary, resources []string) {
+	for _, p := range s.Problems {
+		output.Errorf("%s", p)
+	}
+	if slices.Contains(resources, "fields") {
+		fmt.Printf("Fields:      %d created, %d mapped\n", s.FieldsCreated, s.FieldsMapped)
+	}
+	if slices.Contains(resources, "groups") {
+		fmt.Printf("Groups:      %d created, %d mapped\n", s.GroupsCreated, s.GroupsMapped)
+	}
+	if slices.Contains(resources, "subscribers") {
+		fmt.Printf("Subscribers: %d migrated, %d failed\n", s.Migrated, len(s.Failed))
+	}
+
+	emails := make([]string, 0, len(s.Failed))
+	for email := range s.Failed {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+	for _, email := range emails {
+		output.Errorf("%s: %s", email, s.Failed[email])
+	}
+}
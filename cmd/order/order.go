@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/ecommerce"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
@@ -70,9 +71,9 @@ var listCmd = &cobra.Command{
 		}
 
 		limit, _ := cmd.Flags().GetInt("limit")
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		orders, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.Order, bool, error) {
+		orders := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]ecommerce.Order, bool, error) {
 			path := fmt.Sprintf("/ecommerce/shops/%s/orders?page=%d&limit=%d", shopID, page, perPage)
 			var result ecommerce.RootOrders
 			_, err := sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -80,30 +81,9 @@ var listCmd = &cobra.Command{
 				return nil, false, err
 			}
 			return result.Data, !result.Links.IsLastPage(), nil
-		}, limit)
-		if err != nil {
-			return err
-		}
-
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(orders)
-		}
+		}, cmdutil.PageOptions(cmd, limit))
 
-		headers := []string{"ID", "CUSTOMER", "STATUS", "TOTAL", "CURRENCY", "CREATED"}
-		var rows [][]string
-		for _, o := range orders {
-			rows = append(rows, []string{
-				o.ID,
-				o.CustomerID,
-				o.Status,
-				strconv.FormatFloat(o.Total, 'f', 2, 64),
-				o.Currency,
-				o.CreatedAt,
-			})
-		}
-
-		output.Table(headers, rows)
-		return nil
+		return cmdutil.PrintList(cmd, columns.Order, orders)
 	},
 }
 
@@ -123,7 +103,7 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/orders/%s", shopID, args[0])
 		var result ecommerce.RootOrder
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -131,8 +111,8 @@ var getCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		o := result.Data
@@ -198,7 +178,7 @@ var createCmd = &cobra.Command{
 			body["items"] = items
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/orders", shopID)
 		var result ecommerce.RootOrder
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPost, path, body, &result)
@@ -206,8 +186,8 @@ var createCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		output.Success(fmt.Sprintf("Order created: %s (ID: %s)", result.Data.Status, result.Data.ID))
@@ -260,7 +240,7 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("no flags provided; use --help to see available options")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/orders/%s", shopID, args[0])
 		var result ecommerce.RootOrder
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodPut, path, body, &result)
@@ -268,8 +248,8 @@ var updateCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result.Data)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result.Data)
 		}
 
 		output.Success(fmt.Sprintf("Order updated: %s (ID: %s)", result.Data.Status, result.Data.ID))
@@ -293,7 +273,7 @@ var deleteCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/orders/%s", shopID, args[0])
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodDelete, path, nil, nil)
 		if err != nil {
@@ -320,7 +300,7 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		path := fmt.Sprintf("/ecommerce/shops/%s/orders?limit=0", shopID)
 		var result ecommerce.RootCount
 		_, err = sdkclient.DoRaw(ctx, httpClient, apiKey, http.MethodGet, path, nil, &result)
@@ -328,8 +308,8 @@ var countCmd = &cobra.Command{
 			return err
 		}
 
-		if cmdutil.JSONFlag(cmd) {
-			return output.JSON(result)
+		if cmdutil.StructuredOutput(cmd) {
+			return cmdutil.Print(cmd, result)
 		}
 
 		fmt.Printf("Total orders: %d\n", result.Total)
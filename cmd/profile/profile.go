@@ -2,7 +2,9 @@ package profile
 
 import (
 	"fmt"
+	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
 	"github.com/mailerlite/mailerlite-cli/internal/config"
@@ -45,12 +47,14 @@ var removeCmd = &cobra.Command{
 
 func init() {
 	addCmd.Flags().String("token", "", "API token for this profile")
+	addCmd.Flags().String("base-url", "", "API base URL for this profile, e.g. a staging or mock server (default: production)")
 	Cmd.AddCommand(addCmd, listCmd, switchCmd, removeCmd)
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
 	name := args[0]
 	token, _ := cmd.Flags().GetString("token")
+	baseURL, _ := cmd.Flags().GetString("base-url")
 
 	if token == "" && prompt.IsInteractive() {
 		var err error
@@ -80,7 +84,14 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	cfg.Profiles[name] = config.Profile{APIToken: token}
+	if baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid --base-url %q: must be an http or https URL", baseURL)
+		}
+	}
+
+	cfg.Profiles[name] = config.Profile{APIToken: token, BaseURL: strings.TrimSuffix(baseURL, "/")}
 	if cfg.ActiveProfile == "" {
 		cfg.ActiveProfile = name
 	}
@@ -99,17 +110,17 @@ func runList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	jsonFlag, _ := cmd.Root().PersistentFlags().GetBool("json")
-	if jsonFlag {
+	if cmdutil.StructuredOutput(cmd) {
 		profiles := make([]map[string]interface{}, 0, len(cfg.Profiles))
 		for name, p := range cfg.Profiles {
 			profiles = append(profiles, map[string]interface{}{
 				"name":      name,
 				"active":    name == cfg.ActiveProfile,
 				"has_token": p.APIToken != "",
+				"base_url":  p.BaseURL,
 			})
 		}
-		return output.JSON(profiles)
+		return cmdutil.Print(cmd, profiles)
 	}
 
 	if len(cfg.Profiles) == 0 {
@@ -129,10 +140,14 @@ func runList(cmd *cobra.Command, args []string) error {
 		if name == cfg.ActiveProfile {
 			active = "*"
 		}
-		rows = append(rows, []string{active, name, "token"})
+		baseURL := cfg.Profiles[name].BaseURL
+		if baseURL == "" {
+			baseURL = "default"
+		}
+		rows = append(rows, []string{active, name, "token", baseURL})
 	}
 
-	output.Table([]string{"", "NAME", "METHOD"}, rows)
+	output.Table([]string{"", "NAME", "METHOD", "BASE URL"}, rows)
 	return nil
 }
 
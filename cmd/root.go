@@ -1,9 +1,22 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
 	"github.com/mailerlite/mailerlite-cli/cmd/account"
+	"github.com/mailerlite/mailerlite-cli/cmd/api"
+	"github.com/mailerlite/mailerlite-cli/cmd/apply"
 	"github.com/mailerlite/mailerlite-cli/cmd/auth"
 	"github.com/mailerlite/mailerlite-cli/cmd/automation"
+	"github.com/mailerlite/mailerlite-cli/cmd/backup"
+	"github.com/mailerlite/mailerlite-cli/cmd/batch"
 	"github.com/mailerlite/mailerlite-cli/cmd/campaign"
 	"github.com/mailerlite/mailerlite-cli/cmd/cart"
 	"github.com/mailerlite/mailerlite-cli/cmd/cartitem"
@@ -11,10 +24,13 @@ import (
 	"github.com/mailerlite/mailerlite-cli/cmd/completion"
 	"github.com/mailerlite/mailerlite-cli/cmd/customer"
 	"github.com/mailerlite/mailerlite-cli/cmd/dashboard"
+	"github.com/mailerlite/mailerlite-cli/cmd/dev"
+	"github.com/mailerlite/mailerlite-cli/cmd/diff"
 	"github.com/mailerlite/mailerlite-cli/cmd/field"
 	"github.com/mailerlite/mailerlite-cli/cmd/form"
 	"github.com/mailerlite/mailerlite-cli/cmd/group"
 	importcmd "github.com/mailerlite/mailerlite-cli/cmd/import"
+	"github.com/mailerlite/mailerlite-cli/cmd/migrate"
 	"github.com/mailerlite/mailerlite-cli/cmd/order"
 	"github.com/mailerlite/mailerlite-cli/cmd/product"
 	"github.com/mailerlite/mailerlite-cli/cmd/profile"
@@ -24,6 +40,9 @@ import (
 	"github.com/mailerlite/mailerlite-cli/cmd/timezone"
 	"github.com/mailerlite/mailerlite-cli/cmd/webhook"
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/logging"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
 	"github.com/spf13/cobra"
 )
 
@@ -33,15 +52,54 @@ var rootCmd = &cobra.Command{
 	Long:          "A command-line interface for the MailerLite API. Manage subscribers, campaigns, automations, groups, forms, and more.",
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := cmdutil.ParseOutputFlags(cmd); err != nil {
+			return err
+		}
+		if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			cmd.SetContext(ctx)
+			cancelTimeout = cancel
+			commandTimeout = timeout
+		}
+		_, err := cmdutil.Logger(cmd)
+		return err
+	},
 }
 
+// ErrInterrupted is returned by Execute when the command was stopped with
+// Ctrl-C or SIGTERM.
+var ErrInterrupted = errors.New("interrupted")
+
+// cancelTimeout releases the --timeout context once the command finishes,
+// and commandTimeout is kept for the error message.
+var (
+	cancelTimeout  context.CancelFunc = func() {}
+	commandTimeout time.Duration
+)
+
 func init() {
 	rootCmd.Version = version
 	cmdutil.SetVersion(version)
 	rootCmd.PersistentFlags().String("profile", "", "config profile to use")
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "show HTTP request/response details")
-	rootCmd.PersistentFlags().Bool("json", false, "output as JSON")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "log HTTP requests and responses to stderr")
+	rootCmd.PersistentFlags().String("log-file", "", "write the HTTP debug log to this file instead of stderr")
+	rootCmd.PersistentFlags().String("log-format", logging.FormatText, "debug log format: "+strings.Join(logging.Formats, ", "))
+	rootCmd.PersistentFlags().Bool("json", false, "output as JSON (shorthand for --output json)")
+	rootCmd.PersistentFlags().StringP("output", "o", output.FormatTable, "output format: "+strings.Join(output.Formats, ", "))
+	rootCmd.PersistentFlags().String("query", "", "JMESPath expression to filter JSON output, e.g. 'data[].email'")
+	rootCmd.PersistentFlags().StringSlice("columns", nil, "columns to show in list output, e.g. id,email,fields.city")
+	rootCmd.PersistentFlags().String("sort-by", "", "sort list output by a column; prefix with - for descending order")
+	rootCmd.PersistentFlags().Bool("no-headers", false, "omit the header row from table, CSV and TSV output")
 	rootCmd.PersistentFlags().BoolP("yes", "y", false, "skip confirmation prompts")
+	rootCmd.PersistentFlags().String("record", "", "save every API request and response as fixtures in this directory")
+	rootCmd.PersistentFlags().String("replay", "", "answer API requests from fixtures saved with --record instead of the network")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "abort the command if it runs longer than this, e.g. 30s or 5m (0 means no limit)")
+	rootCmd.PersistentFlags().Int("requests-per-minute", sdkclient.DefaultRequestsPerMinute, "client-side limit on API requests per minute, shared by concurrent requests (0 disables)")
+	rootCmd.PersistentFlags().Int("max-retries", sdkclient.DefaultRetryPolicy.MaxRetries, "retries for rate-limited, 5xx and failed requests")
+	rootCmd.PersistentFlags().Duration("max-retry-wait", sdkclient.DefaultRetryPolicy.MaxWait, "maximum total time to wait between retries of one request")
+	rootCmd.PersistentFlags().Int("page-size", sdkclient.DefaultPageSize, fmt.Sprintf("items requested per API page when listing (max %d)", sdkclient.MaxPageSize))
 
 	rootCmd.AddCommand(dashboard.Cmd)
 	rootCmd.AddCommand(subscriber.Cmd)
@@ -61,15 +119,42 @@ func init() {
 	rootCmd.AddCommand(cart.Cmd)
 	rootCmd.AddCommand(cartitem.Cmd)
 	rootCmd.AddCommand(importcmd.Cmd)
+	rootCmd.AddCommand(batch.Cmd)
+	rootCmd.AddCommand(api.Cmd)
+	rootCmd.AddCommand(apply.Cmd)
+	rootCmd.AddCommand(backup.Cmd)
+	rootCmd.AddCommand(migrate.Cmd)
+	rootCmd.AddCommand(diff.Cmd)
 	rootCmd.AddCommand(account.Cmd)
 	rootCmd.AddCommand(auth.Cmd)
 	rootCmd.AddCommand(profile.Cmd)
+	rootCmd.AddCommand(dev.Cmd)
 	rootCmd.AddCommand(completion.Cmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
+// Execute runs the root command with a context that is cancelled on Ctrl-C
+// or SIGTERM. A second signal terminates the process immediately. Stopping
+// a command that runs until stopped, such as the mock server, is not an
+// error.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	c, err := rootCmd.ExecuteContextC(ctx)
+	cancelTimeout()
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil && c != nil && cmdutil.RunsUntilStopped(c):
+		return nil
+	case ctx.Err() != nil:
+		return ErrInterrupted
+	case errors.Is(err, context.DeadlineExceeded) && commandTimeout > 0:
+		return fmt.Errorf("timed out after %s", commandTimeout)
+	}
+	return err
 }
 
 func IsJSON() bool {
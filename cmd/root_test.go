@@ -0,0 +1,490 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mailerlite/mailerlite-cli/internal/mockapi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// requestLog counts the requests reaching the mock API by path and status.
+type requestLog struct {
+	mu       sync.Mutex
+	pages    map[string]int
+	statuses map[int]int
+}
+
+func (l *requestLog) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.pages[r.URL.Path]++
+		l.statuses[rec.status]++
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// startMock serves a mock API for the duration of the test and points the
+// CLI at it through the environment.
+func startMock(t *testing.T, opts mockapi.Options) *requestLog {
+	t.Helper()
+	log := &requestLog{pages: map[string]int{}, statuses: map[int]int{}}
+	srv := httptest.NewServer(log.wrap(mockapi.New(opts)))
+	t.Cleanup(srv.Close)
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("MAILERLITE_API_BASE_URL", srv.URL+"/api")
+	t.Setenv("MAILERLITE_API_TOKEN", "test")
+	return log
+}
+
+// run executes the command tree with args and returns what it wrote to
+// stdout. The client-side rate limiter is disabled so that the mock's own
+// limit is what the CLI runs into.
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+
+	resetCommands(rootCmd)
+	rootCmd.SetArgs(append([]string{"--requests-per-minute", "0"}, args...))
+	runErr := Execute()
+	w.Close() //nolint:errcheck
+	return <-out, runErr
+}
+
+// runProcess executes the command tree with args in a new process, for
+// flags such as --verbose that are read once per process, and returns what
+// it wrote to stdout and stderr.
+func runProcess(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	cmd := helperCommand(t, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
+
+// helperCommand returns a command that runs the command tree with args in
+// TestHelperProcess.
+func helperCommand(t *testing.T, args ...string) *exec.Cmd {
+	t.Helper()
+	argv, err := json.Marshal(append([]string{"--requests-per-minute", "0"}, args...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperProcess$")
+	cmd.Env = append(os.Environ(), "MAILERLITE_TEST_ARGS="+string(argv))
+	return cmd
+}
+
+// TestHelperProcess is not a test: it is the process started by runProcess.
+func TestHelperProcess(t *testing.T) {
+	argv := os.Getenv("MAILERLITE_TEST_ARGS")
+	if argv == "" {
+		return
+	}
+	var args []string
+	if err := json.Unmarshal([]byte(argv), &args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	rootCmd.SetArgs(args)
+	if err := Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// resetCommands undoes what an earlier run left behind in the command tree,
+// which a process normally runs only once: flag values and the contexts of
+// subcommands, which cobra only sets from the root when they have none.
+func resetCommands(c *cobra.Command) {
+	c.SetContext(nil) //nolint:staticcheck // nil makes cobra pass down the root context
+	reset := func(f *pflag.Flag) {
+		if f.Changed {
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				_ = sv.Replace(nil)
+			} else {
+				_ = f.Value.Set(f.DefValue)
+			}
+			f.Changed = false
+		}
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetCommands(sub)
+	}
+}
+
+func TestSubscriberListPaginates(t *testing.T) {
+	log := startMock(t, mockapi.Options{})
+
+	out, err := run(t, "subscriber", "list", "--limit", "0", "--page-size", "25", "-o", "json")
+	if err != nil {
+		t.Fatalf("subscriber list: %v", err)
+	}
+	var subscribers []struct {
+		ID    string `json:"id"`
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal([]byte(out), &subscribers); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if len(subscribers) != 150 {
+		t.Errorf("got %d subscribers, want 150", len(subscribers))
+	}
+	seen := map[string]bool{}
+	for _, s := range subscribers {
+		if seen[s.ID] {
+			t.Errorf("subscriber %s listed twice", s.ID)
+		}
+		seen[s.ID] = true
+	}
+	if pages := log.pages["/api/subscribers"]; pages != 6 {
+		t.Errorf("fetched %d pages, want 6", pages)
+	}
+}
+
+func TestRetriesRateLimitedRequests(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits for the mock's rate limit window")
+	}
+	log := startMock(t, mockapi.Options{RateLimit: 1, RateWindow: time.Second})
+
+	out, err := run(t, "group", "list", "--limit", "0", "--page-size", "2", "-o", "json", "--query", "[].name")
+	if err != nil {
+		t.Fatalf("group list: %v", err)
+	}
+	if log.statuses[http.StatusTooManyRequests] == 0 {
+		t.Fatalf("the mock never answered 429 (responses: %v); the test does not exercise retries", log.statuses)
+	}
+	var names []string
+	if err := json.Unmarshal([]byte(out), &names); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	// The mock is seeded with 5 groups.
+	if len(names) != 5 {
+		t.Errorf("got %d groups, want 5: %q", len(names), names)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	startMock(t, mockapi.Options{})
+	dir := t.TempDir()
+
+	recorded, err := run(t, "group", "list", "--page-size", "2", "--limit", "0", "-o", "json", "--record", dir)
+	if err != nil {
+		t.Fatalf("group list --record: %v", err)
+	}
+	fixtures, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(fixtures) != 3 {
+		t.Fatalf("recorded %d fixtures, want one per page (3)", len(fixtures))
+	}
+	for _, name := range fixtures {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "Bearer test") {
+			t.Errorf("%s contains the API token", filepath.Base(name))
+		}
+	}
+
+	// Replay against an address nothing listens on: fixtures are matched on
+	// method, path and query, not on the host.
+	t.Setenv("MAILERLITE_API_BASE_URL", "http://127.0.0.1:1/api")
+	replayed, err := run(t, "group", "list", "--page-size", "2", "--limit", "0", "-o", "json", "--replay", dir)
+	if err != nil {
+		t.Fatalf("group list --replay: %v", err)
+	}
+	if replayed != recorded {
+		t.Errorf("replayed output differs from recorded output:\n%s\nwant:\n%s", replayed, recorded)
+	}
+
+	_, err = run(t, "group", "list", "--page-size", "5", "-o", "json", "--replay", dir)
+	if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("replaying an unrecorded request: got %v, want a no recorded response error", err)
+	}
+}
+
+func TestVerboseRedactsLogs(t *testing.T) {
+	startMock(t, mockapi.Options{})
+	const token = "mlsecret-0123456789"
+	t.Setenv("MAILERLITE_API_TOKEN", token)
+
+	_, stderr, err := runProcess(t, "subscriber", "upsert", "--email", "jane.doe@example.com", "--verbose")
+	if err != nil {
+		t.Fatalf("subscriber upsert: %v\n%s", err, stderr)
+	}
+	if !strings.Contains(stderr, "http request") {
+		t.Fatalf("--verbose logged no requests:\n%s", stderr)
+	}
+	if strings.Contains(stderr, token) {
+		t.Errorf("log contains the API token:\n%s", stderr)
+	}
+	if strings.Contains(stderr, "jane.doe@example.com") {
+		t.Errorf("log contains the email address:\n%s", stderr)
+	}
+	if !strings.Contains(stderr, "j***@example.com") {
+		t.Errorf("log does not contain the redacted email address:\n%s", stderr)
+	}
+}
+
+func TestListColumns(t *testing.T) {
+	startMock(t, mockapi.Options{})
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "sorted descending without headers",
+			args: []string{"--columns", "name", "--sort-by", "-name", "--no-headers", "-o", "tsv"},
+			want: "Webinar attendees\nVIP\nNewsletter\nCustomers\nBeta testers\n",
+		},
+		{
+			name: "sorted ascending with headers",
+			args: []string{"--columns", "name", "--sort-by", "name", "-o", "csv"},
+			want: "NAME\nBeta testers\nCustomers\nNewsletter\nVIP\nWebinar attendees\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := run(t, append([]string{"group", "list"}, tt.args...)...)
+			if err != nil {
+				t.Fatalf("group list: %v", err)
+			}
+			if out != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", out, tt.want)
+			}
+		})
+	}
+
+	if _, err := run(t, "group", "list", "--columns", "nope"); err == nil {
+		t.Error("unknown column: got no error")
+	}
+}
+
+func TestMockServerStopsCleanlyOnInterrupt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("cannot send os.Interrupt on Windows")
+	}
+	t.Setenv("HOME", t.TempDir())
+	cmd := helperCommand(t, "dev", "mock-server", "--addr", "127.0.0.1:0")
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	lines := bufio.NewScanner(stderr)
+	if !lines.Scan() || !strings.Contains(lines.Text(), "listening") {
+		cmd.Process.Kill() //nolint:errcheck
+		t.Fatalf("mock server did not start: %q", lines.Text())
+	}
+	var rest strings.Builder
+	for lines.Scan() {
+		if strings.HasPrefix(lines.Text(), "  export MAILERLITE_API_TOKEN") {
+			break
+		}
+	}
+
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	for lines.Scan() {
+		rest.WriteString(lines.Text() + "\n")
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Errorf("mock server exited with %v after Ctrl-C, want success; stderr:\n%s", err, rest.String())
+	}
+	if strings.Contains(rest.String(), "interrupted") {
+		t.Errorf("mock server reported an error after Ctrl-C:\n%s", rest.String())
+	}
+}
+
+// withStdin makes input the standard input for the rest of the test.
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(name, []byte(input), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		f.Close() //nolint:errcheck
+	})
+}
+
+func TestListOutputPipesIntoBulkCommands(t *testing.T) {
+	startMock(t, mockapi.Options{})
+
+	out, err := run(t, "group", "create", "--name", "Piped", "-o", "json", "--query", "data.id")
+	if err != nil {
+		t.Fatalf("group create: %v", err)
+	}
+	var groupID string
+	if err := json.Unmarshal([]byte(out), &groupID); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+
+	// The pipeline from the group unassign and bulk operations examples.
+	ids, err := run(t, "subscriber", "list", "--limit", "10", "--columns", "id", "--no-headers", "-o", "tsv")
+	if err != nil {
+		t.Fatalf("subscriber list: %v", err)
+	}
+	want := strings.Fields(ids)
+	if len(want) != 10 {
+		t.Fatalf("listed %d IDs, want 10:\n%s", len(want), ids)
+	}
+	withStdin(t, ids)
+	out, err = run(t, "group", "assign", groupID, "--file", "-", "--yes", "-o", "json", "--query", "[succeeded, failed]")
+	if err != nil {
+		t.Fatalf("group assign --file -: %v", err)
+	}
+	if got := strings.Join(strings.Fields(out), ""); got != "[10,0]" {
+		t.Errorf("assigned [succeeded, failed] = %s, want [10,0]", got)
+	}
+
+	out, err = run(t, "group", "subscribers", groupID, "--limit", "0", "-o", "json", "--query", "[].id")
+	if err != nil {
+		t.Fatalf("group subscribers: %v", err)
+	}
+	var got []string
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("group has subscribers %q, want %q", got, want)
+	}
+}
+
+func TestScheduleAtFindsTimezoneByOffset(t *testing.T) {
+	startMock(t, mockapi.Options{})
+	out, err := run(t, "campaign", "list", "--status", "draft", "--limit", "1", "-o", "json", "--query", "[0].id")
+	if err != nil {
+		t.Fatalf("campaign list: %v", err)
+	}
+	var id string
+	if err := json.Unmarshal([]byte(out), &id); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+
+	tests := []struct {
+		tz, want string
+	}{
+		// The mock has Europe/Berlin but not Europe/Paris, which always has
+		// the same offset.
+		{"Europe/Paris", "(Europe/Berlin)"},
+		{"Europe/Vilnius", "(Europe/Vilnius)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tz, func(t *testing.T) {
+			t.Setenv("TZ", tt.tz)
+			stdout, stderr, err := runProcess(t, "campaign", "schedule", id, "--at", "tomorrow 9am", "--force", "--yes")
+			if err != nil {
+				t.Fatalf("campaign schedule: %v\n%s%s", err, stdout, stderr)
+			}
+			if !strings.Contains(stdout, tt.want) {
+				t.Errorf("scheduled in the wrong timezone, want %s:\n%s", tt.want, stdout)
+			}
+		})
+	}
+
+	t.Setenv("TZ", "Asia/Kolkata")
+	if _, stderr, err := runProcess(t, "campaign", "schedule", id, "--at", "tomorrow 9am", "--force", "--yes"); err == nil {
+		t.Error("scheduled although the mock has no timezone at UTC+05:30")
+	} else if !strings.Contains(stderr, "no timezone at UTC+05:30") {
+		t.Errorf("unexpected error:\n%s", stderr)
+	}
+}
+
+func TestResendCleansUpAfterFailure(t *testing.T) {
+	startMock(t, mockapi.Options{})
+	out, err := run(t, "campaign", "list", "--status", "draft", "--limit", "1", "-o", "json", "--query", "[0].id")
+	if err != nil {
+		t.Fatalf("campaign list: %v", err)
+	}
+	var id string
+	if err := json.Unmarshal([]byte(out), &id); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if _, err := run(t, "campaign", "schedule", id, "--force", "--yes"); err != nil {
+		t.Fatalf("campaign schedule: %v", err)
+	}
+
+	// The copy cannot be scheduled without a timezone at UTC+05:30, after
+	// the group was filled and the draft created.
+	t.Setenv("TZ", "Asia/Kolkata")
+	if _, stderr, err := runProcess(t, "campaign", "resend", id, "--after", "48h", "--yes"); err == nil {
+		t.Fatal("resend succeeded although the copy could not be scheduled")
+	} else if !strings.Contains(stderr, "no timezone at UTC+05:30") {
+		t.Fatalf("unexpected error:\n%s", stderr)
+	}
+
+	if out, err := run(t, "group", "list", "-o", "json", "--query", "[?contains(name, 'non-openers')].id"); err != nil {
+		t.Fatalf("group list: %v", err)
+	} else if strings.TrimSpace(out) != "[]" {
+		t.Errorf("resend left a group behind: %s", out)
+	}
+	if out, err := run(t, "campaign", "list", "-o", "json", "--query", "[?contains(name, '(resend)')].id"); err != nil {
+		t.Fatalf("campaign list: %v", err)
+	} else if strings.TrimSpace(out) != "[]" {
+		t.Errorf("resend left a draft behind: %s", out)
+	}
+}
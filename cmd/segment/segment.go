@@ -3,9 +3,9 @@ package segment
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
@@ -51,9 +51,9 @@ func runList(c *cobra.Command, args []string) error {
 
 	limit, _ := c.Flags().GetInt("limit")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	allSegments, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Segment, bool, error) {
+	allSegments := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Segment, bool, error) {
 		opts := &mailerlite.ListSegmentOptions{
 			Page:  page,
 			Limit: perPage,
@@ -63,31 +63,9 @@ func runList(c *cobra.Command, args []string) error {
 			return nil, false, sdkclient.WrapError(err)
 		}
 		return root.Data, !root.Links.IsLastPage(), nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(allSegments)
-	}
-
-	headers := []string{"ID", "NAME", "TOTAL", "OPEN RATE", "CLICK RATE", "CREATED AT"}
-	var rows [][]string
-
-	for _, s := range allSegments {
-		rows = append(rows, []string{
-			s.ID,
-			s.Name,
-			strconv.Itoa(s.Total),
-			s.OpenRate.String,
-			s.ClickRate.String,
-			s.CreatedAt,
-		})
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Segment, allSegments)
 }
 
 // --- update ---
@@ -111,14 +89,14 @@ func runUpdate(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Segment.Update(ctx, args[0], name)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Segment " + args[0] + " updated successfully.")
@@ -150,7 +128,7 @@ func runDelete(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	_, err = ml.Segment.Delete(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
@@ -178,9 +156,9 @@ func runSubscribers(c *cobra.Command, args []string) error {
 	limit, _ := c.Flags().GetInt("limit")
 	segmentID := args[0]
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	allSubscribers, err := sdkclient.FetchAllCursor(ctx, func(ctx context.Context, after, perPage int) ([]mailerlite.Subscriber, int, error) {
+	allSubscribers := sdkclient.IterateCursor(ctx, func(ctx context.Context, after, perPage int) ([]mailerlite.Subscriber, int, error) {
 		opts := &mailerlite.ListSegmentSubscriberOptions{
 			SegmentID: segmentID,
 			Limit:     perPage,
@@ -195,28 +173,7 @@ func runSubscribers(c *cobra.Command, args []string) error {
 			nextAfter = root.Meta.Last
 		}
 		return root.Data, nextAfter, nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(allSubscribers)
-	}
-
-	headers := []string{"ID", "EMAIL", "STATUS", "SUBSCRIBED AT", "CREATED AT"}
-	var rows [][]string
-
-	for _, s := range allSubscribers {
-		rows = append(rows, []string{
-			s.ID,
-			s.Email,
-			s.Status,
-			s.SubscribedAt,
-			s.CreatedAt,
-		})
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Subscriber.WithDefaults("id", "email", "status", "subscribed_at", "created_at"), allSubscribers)
 }
@@ -0,0 +1,305 @@
+package subscriber
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/spf13/cobra"
+)
+
+// exportColumns are the built-in subscriber columns written before the
+// account's custom fields in CSV exports. Names match the API keys so that
+// an export can be fed back into subscriber import unchanged.
+var exportColumns = []string{
+	"id", "email", "status", "source", "sent", "opens_count", "clicks_count",
+	"open_rate", "click_rate", "ip_address", "subscribed_at", "unsubscribed_at",
+	"created_at", "updated_at", "opted_in_at", "optin_ip",
+}
+
+// --- export ---
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export subscribers to a CSV or JSONL file",
+	Long: `Export subscribers page by page, writing each page to disk as it arrives.
+
+CSV exports flatten custom fields into one column per field key. JSONL exports
+write one subscriber object per line. When writing to a file, progress is
+tracked in <file>.state; if the export is interrupted, re-run the same command
+with --resume to continue from the last completed page.`,
+	Example: `  mailerlite subscriber export --file subscribers.csv
+  mailerlite subscriber export --format jsonl --status active --file active.jsonl
+  mailerlite subscriber export --file subscribers.csv --resume`,
+	RunE: runExport,
+}
+
+// exportState is persisted next to the output file after every page so an
+// interrupted export can be resumed.
+type exportState struct {
+	Format  string `json:"format"`
+	Status  string `json:"status,omitempty"`
+	Email   string `json:"email,omitempty"`
+	Cursor  string `json:"cursor"`
+	Written int    `json:"written"`
+	// Size is the length of the output file when the state was saved; a
+	// resumed export truncates the file to it to drop a partly written page.
+	Size int64 `json:"size"`
+	// FieldKeys are the custom field columns of a CSV export, as in its
+	// header.
+	FieldKeys []string `json:"field_keys,omitempty"`
+}
+
+func runExport(c *cobra.Command, _ []string) error {
+	ml, err := cmdutil.NewSDKClient(c)
+	if err != nil {
+		return err
+	}
+
+	format, _ := c.Flags().GetString("format")
+	filePath, _ := c.Flags().GetString("file")
+	status, _ := c.Flags().GetString("status")
+	email, _ := c.Flags().GetString("email")
+	resume, _ := c.Flags().GetBool("resume")
+
+	if format == "" {
+		format = "csv"
+		if filePath != "" {
+			format = formatFromPath(filePath)
+		}
+	}
+	if format != "csv" && format != "jsonl" {
+		return fmt.Errorf("unsupported format %q: use csv or jsonl", format)
+	}
+	if resume && filePath == "" {
+		return fmt.Errorf("--resume requires --file")
+	}
+
+	// Exports default to the largest page size rather than the global default.
+	pageSize := sdkclient.MaxPageSize
+	if c.Flags().Changed("page-size") {
+		pageSize = cmdutil.PageOptions(c, 0).PageSize
+	}
+
+	ctx := c.Context()
+
+	state := exportState{Format: format, Status: status, Email: email}
+	statePath := filePath + ".state"
+	if resume {
+		prev, err := readExportState(statePath)
+		if err != nil {
+			return err
+		}
+		if prev.Format != format || prev.Status != status || prev.Email != email {
+			return fmt.Errorf("export options differ from the interrupted export in %s", statePath)
+		}
+		state = prev
+	}
+
+	fieldKeys := state.FieldKeys
+	if format == "csv" && !resume {
+		fields, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Field, bool, error) {
+			root, _, err := ml.Field.List(ctx, &mailerlite.ListFieldOptions{Page: page, Limit: perPage})
+			if err != nil {
+				return nil, false, sdkclient.WrapError(err)
+			}
+			return root.Data, !root.Links.IsLastPage(), nil
+		}, 0)
+		if err != nil {
+			return err
+		}
+		for _, f := range fields {
+			fieldKeys = append(fieldKeys, f.Key)
+		}
+		state.FieldKeys = fieldKeys
+	}
+
+	var out io.Writer = os.Stdout
+	var file *os.File
+	if filePath != "" {
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if resume {
+			flags = os.O_WRONLY
+		}
+		file, err = os.OpenFile(filePath, flags, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", filePath, err)
+		}
+		defer file.Close() //nolint:errcheck
+		if resume {
+			// Drop anything written after the state was last saved.
+			if err := file.Truncate(state.Size); err != nil {
+				return fmt.Errorf("failed to truncate %s: %w", filePath, err)
+			}
+			if _, err := file.Seek(state.Size, io.SeekStart); err != nil {
+				return fmt.Errorf("failed to seek %s: %w", filePath, err)
+			}
+		}
+		out = file
+	}
+
+	w := newSubscriberWriter(out, format, fieldKeys)
+	if !resume {
+		if err := w.WriteHeader(); err != nil {
+			return err
+		}
+	}
+
+	var filters []mailerlite.Filter
+	if status != "" {
+		filters = append(filters, *mailerlite.NewFilter("status", status))
+	}
+	if email != "" {
+		filters = append(filters, *mailerlite.NewFilter("email", email))
+	}
+
+	for {
+		opts := &mailerlite.ListSubscriberOptions{
+			Cursor: state.Cursor,
+			Limit:  pageSize,
+		}
+		if len(filters) > 0 {
+			opts.Filters = &filters
+		}
+
+		root, _, err := ml.Subscriber.List(ctx, opts)
+		if err != nil {
+			// Pages written so far are complete; say how to pick up from there.
+			if ctx.Err() != nil && filePath != "" && state.Written > 0 {
+				output.Errorf("Export stopped after %d subscribers; re-run with --resume to continue.", state.Written)
+			}
+			return sdkclient.WrapError(err)
+		}
+
+		for _, s := range root.Data {
+			if err := w.Write(s); err != nil {
+				return err
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		state.Written += len(root.Data)
+		state.Cursor = root.Meta.NextCursor
+		if state.Cursor == "" || len(root.Data) == 0 {
+			break
+		}
+		if file != nil {
+			if state.Size, err = file.Seek(0, io.SeekCurrent); err != nil {
+				return fmt.Errorf("failed to read the size of %s: %w", filePath, err)
+			}
+			if err := writeExportState(statePath, state); err != nil {
+				return err
+			}
+		}
+	}
+
+	if filePath == "" {
+		return nil
+	}
+	if err := os.Remove(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove %s: %w", statePath, err)
+	}
+
+	output.Success(fmt.Sprintf("Exported %d subscribers to %s.", state.Written, filePath))
+	return nil
+}
+
+func readExportState(path string) (exportState, error) {
+	var state exportState
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, fmt.Errorf("nothing to resume: %s not found", path)
+		}
+		return state, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return state, nil
+}
+
+func writeExportState(path string, state exportState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// subscriberWriter writes subscribers in CSV or JSONL format.
+type subscriberWriter struct {
+	format    string
+	fieldKeys []string
+	csv       *csv.Writer
+	json      *json.Encoder
+}
+
+func newSubscriberWriter(out io.Writer, format string, fieldKeys []string) *subscriberWriter {
+	w := &subscriberWriter{format: format, fieldKeys: fieldKeys}
+	if format == "csv" {
+		w.csv = csv.NewWriter(out)
+	} else {
+		w.json = json.NewEncoder(out)
+	}
+	return w
+}
+
+func (w *subscriberWriter) WriteHeader() error {
+	if w.csv == nil {
+		return nil
+	}
+	return w.csv.Write(append(append([]string{}, exportColumns...), w.fieldKeys...))
+}
+
+func (w *subscriberWriter) Write(s mailerlite.Subscriber) error {
+	if w.json != nil {
+		return w.json.Encode(s)
+	}
+
+	row := []string{
+		s.ID,
+		s.Email,
+		s.Status,
+		s.Source,
+		strconv.Itoa(s.Sent),
+		strconv.Itoa(s.OpensCount),
+		strconv.Itoa(s.ClicksCount),
+		strconv.FormatFloat(s.OpenRate, 'f', -1, 64),
+		strconv.FormatFloat(s.ClickRate, 'f', -1, 64),
+		stringValue(s.IPAddress),
+		s.SubscribedAt,
+		stringValue(s.UnsubscribedAt),
+		s.CreatedAt,
+		s.UpdatedAt,
+		s.OptedInAt,
+		s.OptinIP,
+	}
+	for _, key := range w.fieldKeys {
+		row = append(row, stringValue(s.Fields[key]))
+	}
+	return w.csv.Write(row)
+}
+
+func (w *subscriberWriter) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
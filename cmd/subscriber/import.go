@@ -0,0 +1,536 @@
+package subscriber
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/prompt"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+	"github.com/spf13/cobra"
+)
+
+// builtinImportFields are subscriber attributes that can be mapped from an
+// import file in addition to the account's custom fields.
+var builtinImportFields = []string{"email", "status", "subscribed_at", "ip_address", "opted_in_at", "optin_ip"}
+
+var validStatuses = []string{"active", "unsubscribed", "unconfirmed", "bounced", "junk"}
+
+// --- import ---
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Bulk import subscribers from a CSV or JSONL file",
+	Long: `Stream subscribers from a CSV or JSONL file and upsert them one row at a time.
+
+Columns are matched to subscriber attributes (email, status, subscribed_at,
+ip_address, opted_in_at, optin_ip) and custom field keys by name. Use --map to
+map differently named columns, e.g. --map "E-mail=email,First Name=name", or
+--map "Notes=-" to skip a column. Rows that fail validation or are rejected by
+the API are written to a rejects file in the same format as the input.`,
+	Example: `  mailerlite subscriber import --file list.csv --groups 123,456
+  mailerlite subscriber import --file list.jsonl --map "mail=email,first=name"`,
+	RunE: runImport,
+}
+
+// importResult is the per-row outcome reported by subscriber import.
+type importResult struct {
+	Line   int    `json:"line"`
+	Email  string `json:"email,omitempty"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// importSummary is the final report printed by subscriber import.
+type importSummary struct {
+	Total     int            `json:"total"`
+	Succeeded int            `json:"succeeded"`
+	Failed    int            `json:"failed"`
+	Rejects   string         `json:"rejects_file,omitempty"`
+	Results   []importResult `json:"results"`
+}
+
+func runImport(c *cobra.Command, _ []string) error {
+	ml, err := cmdutil.NewSDKClient(c)
+	if err != nil {
+		return err
+	}
+
+	filePath, _ := c.Flags().GetString("file")
+	filePath, err = prompt.RequireArg(filePath, "file", "Path to CSV or JSONL file")
+	if err != nil {
+		return err
+	}
+
+	format, _ := c.Flags().GetString("format")
+	if format == "" {
+		format = formatFromPath(filePath)
+	}
+	if format != "csv" && format != "jsonl" {
+		return fmt.Errorf("unsupported format %q: use csv or jsonl", format)
+	}
+
+	mapPairs, _ := c.Flags().GetStringSlice("map")
+	groups, _ := c.Flags().GetStringSlice("groups")
+	status, _ := c.Flags().GetString("status")
+	if status != "" && !contains(validStatuses, status) {
+		return fmt.Errorf("invalid status %q: use one of %s", status, strings.Join(validStatuses, ", "))
+	}
+
+	ctx := c.Context()
+
+	fields, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Field, bool, error) {
+		root, _, err := ml.Field.List(ctx, &mailerlite.ListFieldOptions{Page: page, Limit: perPage})
+		if err != nil {
+			return nil, false, sdkclient.WrapError(err)
+		}
+		return root.Data, !root.Links.IsLastPage(), nil
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	mapper, err := newColumnMapper(mapPairs, fields)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer in.Close() //nolint:errcheck
+
+	var reader recordReader
+	if format == "csv" {
+		reader, err = newCSVRecordReader(in)
+		if err != nil {
+			return err
+		}
+	} else {
+		reader = newJSONLRecordReader(in)
+	}
+
+	rejectsPath, _ := c.Flags().GetString("rejects")
+	if rejectsPath == "" {
+		rejectsPath = defaultRejectsPath(filePath, format)
+	}
+	rejects := &rejectsWriter{path: rejectsPath, format: format, header: reader.Header()}
+	defer rejects.Close() //nolint:errcheck
+
+	structured := cmdutil.StructuredOutput(c)
+	summary := importSummary{Results: []importResult{}}
+
+	for {
+		rec, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		summary.Total++
+		res := importResult{Line: rec.line}
+
+		var sub *mailerlite.UpsertSubscriber
+		err = rec.err
+		if err == nil {
+			sub, err = mapper.subscriber(rec.values)
+		}
+		if err == nil {
+			if sub.Status == "" {
+				sub.Status = status
+			}
+			if len(groups) > 0 {
+				sub.Groups = groups
+			}
+			res.Email = sub.Email
+
+			var root *mailerlite.RootSubscriber
+			root, _, err = ml.Subscriber.Upsert(ctx, sub)
+			if err == nil {
+				res.ID = root.Data.ID
+			} else {
+				err = sdkclient.WrapError(err)
+			}
+		}
+
+		if err != nil {
+			res.Status = "failed"
+			res.Error = strings.ReplaceAll(err.Error(), "\n", " ")
+			summary.Failed++
+			if werr := rejects.Write(rec, res.Error); werr != nil {
+				return werr
+			}
+			if !structured {
+				output.Errorf("line %d: %s", rec.line, res.Error)
+			}
+		} else {
+			res.Status = "ok"
+			summary.Succeeded++
+		}
+		summary.Results = append(summary.Results, res)
+	}
+
+	if err := rejects.Close(); err != nil {
+		return err
+	}
+	if rejects.count > 0 {
+		summary.Rejects = rejectsPath
+	}
+
+	for _, col := range mapper.ignored() {
+		if !structured {
+			output.Errorf("column %q does not match a subscriber field and was ignored", col)
+		}
+	}
+
+	if structured {
+		return cmdutil.Print(c, summary)
+	}
+
+	msg := fmt.Sprintf("Imported %d of %d subscribers.", summary.Succeeded, summary.Total)
+	if summary.Failed > 0 {
+		return fmt.Errorf("%s %d failed; see %s", msg, summary.Failed, rejectsPath)
+	}
+	output.Success(msg)
+	return nil
+}
+
+// columnMapper resolves input columns to subscriber attributes or custom
+// field keys and validates values against the account's field types.
+type columnMapper struct {
+	explicit   map[string]string
+	fieldTypes map[string]string
+	unknown    map[string]bool
+}
+
+func newColumnMapper(pairs []string, fields []mailerlite.Field) (*columnMapper, error) {
+	m := &columnMapper{
+		explicit:   make(map[string]string, len(pairs)),
+		fieldTypes: make(map[string]string, len(fields)),
+		unknown:    make(map[string]bool),
+	}
+	for _, f := range fields {
+		m.fieldTypes[f.Key] = f.Type
+	}
+
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid mapping %q: expected column=field", pair)
+		}
+		column, target := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if target != "-" && !contains(builtinImportFields, target) {
+			if _, ok := m.fieldTypes[target]; !ok {
+				return nil, fmt.Errorf("unknown field %q in mapping %q; see 'mailerlite field list'", target, pair)
+			}
+		}
+		m.explicit[column] = target
+	}
+	return m, nil
+}
+
+// target returns the subscriber attribute or field key for a column, or ""
+// if the column should be skipped.
+func (m *columnMapper) target(column string) string {
+	if t, ok := m.explicit[column]; ok {
+		if t == "-" {
+			return ""
+		}
+		return t
+	}
+	key := strings.ToLower(strings.TrimSpace(column))
+	if contains(builtinImportFields, key) {
+		return key
+	}
+	if _, ok := m.fieldTypes[key]; ok {
+		return key
+	}
+	m.unknown[column] = true
+	return ""
+}
+
+// ignored returns the columns that were skipped because they matched nothing.
+func (m *columnMapper) ignored() []string {
+	cols := make([]string, 0, len(m.unknown))
+	for col := range m.unknown {
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+func (m *columnMapper) subscriber(values map[string]interface{}) (*mailerlite.UpsertSubscriber, error) {
+	sub := &mailerlite.UpsertSubscriber{}
+	fields := make(map[string]interface{})
+
+	for column, raw := range values {
+		target := m.target(column)
+		if target == "" {
+			continue
+		}
+		value := stringValue(raw)
+
+		switch target {
+		case "email":
+			sub.Email = strings.TrimSpace(value)
+		case "status":
+			sub.Status = strings.ToLower(strings.TrimSpace(value))
+		case "subscribed_at":
+			sub.SubscribedAt = value
+		case "ip_address":
+			sub.IPAddress = value
+		case "opted_in_at":
+			sub.OptedInAt = value
+		case "optin_ip":
+			sub.OptinIP = value
+		default:
+			if value == "" {
+				continue
+			}
+			if err := validateFieldValue(target, m.fieldTypes[target], value); err != nil {
+				return nil, err
+			}
+			fields[target] = value
+		}
+	}
+
+	if sub.Email == "" {
+		return nil, fmt.Errorf("missing email")
+	}
+	if !strings.Contains(sub.Email, "@") {
+		return nil, fmt.Errorf("invalid email %q", sub.Email)
+	}
+	if sub.Status != "" && !contains(validStatuses, sub.Status) {
+		return nil, fmt.Errorf("invalid status %q", sub.Status)
+	}
+	if len(fields) > 0 {
+		sub.Fields = fields
+	}
+	return sub, nil
+}
+
+func validateFieldValue(key, fieldType, value string) error {
+	switch fieldType {
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("field %s: %q is not a number", key, value)
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("field %s: %q is not a date (YYYY-MM-DD)", key, value)
+		}
+	}
+	return nil
+}
+
+// importRecord is a single row read from an import file.
+type importRecord struct {
+	line    int
+	values  map[string]interface{}
+	csvRow  []string
+	jsonRow []byte
+	err     error // set when the row itself could not be parsed
+}
+
+type recordReader interface {
+	// Header returns the CSV header, or nil for JSONL input.
+	Header() []string
+	// Next returns the next record, or io.EOF when the input is exhausted.
+	Next() (*importRecord, error)
+}
+
+type csvRecordReader struct {
+	r      *csv.Reader
+	header []string
+	line   int
+}
+
+func newCSVRecordReader(r io.Reader) (*csvRecordReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("CSV file is empty")
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	return &csvRecordReader{r: cr, header: header, line: 1}, nil
+}
+
+func (r *csvRecordReader) Header() []string { return r.header }
+
+func (r *csvRecordReader) Next() (*importRecord, error) {
+	row, err := r.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	r.line++
+
+	values := make(map[string]interface{}, len(r.header))
+	for i, col := range r.header {
+		if i < len(row) {
+			values[col] = row[i]
+		}
+	}
+	return &importRecord{line: r.line, values: values, csvRow: row}, nil
+}
+
+type jsonlRecordReader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+func newJSONLRecordReader(r io.Reader) *jsonlRecordReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	return &jsonlRecordReader{s: s}
+}
+
+func (r *jsonlRecordReader) Header() []string { return nil }
+
+func (r *jsonlRecordReader) Next() (*importRecord, error) {
+	for r.s.Scan() {
+		r.line++
+		raw := strings.TrimSpace(r.s.Text())
+		if raw == "" {
+			continue
+		}
+
+		rec := &importRecord{line: r.line, jsonRow: []byte(raw)}
+		var values map[string]interface{}
+		if err := json.Unmarshal(rec.jsonRow, &values); err != nil {
+			// Report malformed lines as rejected rows instead of aborting
+			// the whole import.
+			rec.err = fmt.Errorf("invalid JSON: %w", err)
+			return rec, nil
+		}
+		rec.values = values
+		return rec, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read JSONL: %w", err)
+	}
+	return nil, io.EOF
+}
+
+// rejectsWriter lazily creates the rejects file on the first failed row so
+// that successful imports do not leave an empty file behind.
+type rejectsWriter struct {
+	path   string
+	format string
+	header []string
+	count  int
+
+	f   *os.File
+	csv *csv.Writer
+}
+
+func (w *rejectsWriter) Write(rec *importRecord, reason string) error {
+	if w.f == nil {
+		f, err := os.Create(w.path)
+		if err != nil {
+			return fmt.Errorf("failed to create rejects file: %w", err)
+		}
+		w.f = f
+		if w.format == "csv" {
+			w.csv = csv.NewWriter(f)
+			if err := w.csv.Write(append(append([]string{}, w.header...), "error")); err != nil {
+				return err
+			}
+		}
+	}
+	w.count++
+
+	if w.csv != nil {
+		row := make([]string, len(w.header))
+		copy(row, rec.csvRow)
+		return w.csv.Write(append(row, reason))
+	}
+
+	// Rows that are not JSON objects, including null, are kept as-is.
+	var obj map[string]interface{}
+	if json.Unmarshal(rec.jsonRow, &obj) != nil || obj == nil {
+		obj = map[string]interface{}{"_raw": string(rec.jsonRow)}
+	}
+	obj["_error"] = reason
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = w.f.Write(append(b, '\n'))
+	return err
+}
+
+func (w *rejectsWriter) Close() error {
+	if w.f == nil {
+		return nil
+	}
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	err := w.f.Close()
+	w.f = nil
+	w.csv = nil
+	return err
+}
+
+func formatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return "jsonl"
+	default:
+		return "csv"
+	}
+}
+
+func defaultRejectsPath(path, format string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".rejects." + format
+}
+
+func stringValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -2,11 +2,13 @@ package subscriber
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"strconv"
+	"net/http"
 	"strings"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
@@ -17,7 +19,7 @@ import (
 var Cmd = &cobra.Command{
 	Use:   "subscriber",
 	Short: "Manage subscribers",
-	Long:  "List, view, create, update, and delete subscribers.",
+	Long:  "List, view, create, update, import, export, and delete subscribers.",
 }
 
 func init() {
@@ -28,6 +30,8 @@ func init() {
 	Cmd.AddCommand(updateCmd)
 	Cmd.AddCommand(deleteCmd)
 	Cmd.AddCommand(forgetCmd)
+	Cmd.AddCommand(importCmd)
+	Cmd.AddCommand(exportCmd)
 
 	// list flags
 	listCmd.Flags().Int("limit", 25, "maximum number of subscribers to return (0 = all)")
@@ -44,6 +48,28 @@ func init() {
 	updateCmd.Flags().String("email", "", "subscriber email")
 	updateCmd.Flags().String("status", "", "subscriber status")
 	updateCmd.Flags().StringSlice("fields", nil, "custom fields as key=value pairs")
+	cmdutil.AddBulkFlags(updateCmd, "subscribers")
+
+	// delete flags
+	cmdutil.AddBulkFlags(deleteCmd, "subscribers")
+
+	// forget flags
+	cmdutil.AddBulkFlags(forgetCmd, "subscribers")
+
+	// import flags
+	importCmd.Flags().String("file", "", "path to CSV or JSONL file (required)")
+	importCmd.Flags().String("format", "", "input format: csv or jsonl (default: from file extension)")
+	importCmd.Flags().StringSlice("map", nil, "column mappings as column=field pairs (field \"-\" skips the column)")
+	importCmd.Flags().StringSlice("groups", nil, "group IDs to assign to every imported subscriber")
+	importCmd.Flags().String("status", "", "status for rows without a status column")
+	importCmd.Flags().String("rejects", "", "path for rejected rows (default: <file>.rejects.<format>)")
+
+	// export flags
+	exportCmd.Flags().String("file", "", "output file (default: stdout)")
+	exportCmd.Flags().String("format", "", "output format: csv or jsonl (default: from file extension, else csv)")
+	exportCmd.Flags().String("status", "", "filter by status (active, unsubscribed, unconfirmed, bounced, junk)")
+	exportCmd.Flags().String("email", "", "filter by email address")
+	exportCmd.Flags().Bool("resume", false, "continue an interrupted export from its last completed page")
 }
 
 // --- list ---
@@ -64,7 +90,7 @@ func runList(c *cobra.Command, args []string) error {
 	status, _ := c.Flags().GetString("status")
 	email, _ := c.Flags().GetString("email")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
 	var filters []mailerlite.Filter
 	if status != "" {
@@ -74,7 +100,7 @@ func runList(c *cobra.Command, args []string) error {
 		filters = append(filters, *mailerlite.NewFilter("email", email))
 	}
 
-	subscribers, err := sdkclient.FetchAllStringCursor(ctx, func(ctx context.Context, cursor string, perPage int) ([]mailerlite.Subscriber, string, error) {
+	subscribers := sdkclient.IterateStringCursor(ctx, func(ctx context.Context, cursor string, perPage int) ([]mailerlite.Subscriber, string, error) {
 		opts := &mailerlite.ListSubscriberOptions{
 			Cursor: cursor,
 			Limit:  perPage,
@@ -89,31 +115,9 @@ func runList(c *cobra.Command, args []string) error {
 		}
 
 		return root.Data, root.Meta.NextCursor, nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(subscribers)
-	}
-
-	headers := []string{"EMAIL", "STATUS", "SOURCE", "OPENS", "CLICKS", "SUBSCRIBED AT"}
-	var rows [][]string
-
-	for _, s := range subscribers {
-		rows = append(rows, []string{
-			output.Truncate(s.Email, 40),
-			s.Status,
-			s.Source,
-			strconv.Itoa(s.OpensCount),
-			strconv.Itoa(s.ClicksCount),
-			s.SubscribedAt,
-		})
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Subscriber, subscribers)
 }
 
 // --- count ---
@@ -130,14 +134,14 @@ func runCount(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Subscriber.Count(ctx)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	fmt.Printf("Total subscribers: %d\n", result.Total)
@@ -166,14 +170,14 @@ func runGet(c *cobra.Command, args []string) error {
 		opts.SubscriberID = args[0]
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Subscriber.Get(ctx, opts)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	s := result.Data
@@ -249,14 +253,14 @@ func runUpsert(c *cobra.Command, args []string) error {
 		subscriber.Fields = fields
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Subscriber.Upsert(ctx, subscriber)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Subscriber upserted successfully. ID: " + result.Data.ID)
@@ -266,10 +270,17 @@ func runUpsert(c *cobra.Command, args []string) error {
 // --- update ---
 
 var updateCmd = &cobra.Command{
-	Use:   "update <id>",
-	Short: "Update a subscriber",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runUpdate,
+	Use:   "update <subscriber>...",
+	Short: "Update subscribers",
+	Long: `Update one or more subscribers, given by ID or email address. Every
+subscriber gets the same changes.
+
+Pass many subscribers with --file, one per line, or --file - to read them
+from stdin.`,
+	Example: `  mailerlite subscriber update 123 --fields city=Vilnius
+  mailerlite subscriber update --file bounced.txt --status unsubscribed`,
+	Args: cmdutil.BulkArgs(0),
+	RunE: runUpdate,
 }
 
 func runUpdate(c *cobra.Command, args []string) error {
@@ -278,48 +289,87 @@ func runUpdate(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	subscriber := &mailerlite.UpdateSubscriber{
-		ID: args[0],
+	items, err := cmdutil.BulkItems(c, args)
+	if err != nil {
+		return err
 	}
 
+	var email, status string
+	var fields map[string]interface{}
 	if c.Flags().Changed("email") {
-		email, _ := c.Flags().GetString("email")
-		subscriber.Email = email
+		if len(items) > 1 {
+			return fmt.Errorf("--email can only be changed for a single subscriber")
+		}
+		email, _ = c.Flags().GetString("email")
 	}
 	if c.Flags().Changed("status") {
-		status, _ := c.Flags().GetString("status")
-		subscriber.Status = status
+		status, _ = c.Flags().GetString("status")
 	}
 	if c.Flags().Changed("fields") {
 		fieldPairs, _ := c.Flags().GetStringSlice("fields")
-		fields, err := parseFields(fieldPairs)
+		fields, err = parseFields(fieldPairs)
 		if err != nil {
 			return err
 		}
-		subscriber.Fields = fields
 	}
 
-	ctx := context.Background()
-	result, _, err := ml.Subscriber.Update(ctx, subscriber)
+	update := func(ctx context.Context, item string) (*mailerlite.RootSubscriber, error) {
+		id, err := sdkclient.SubscriberID(ctx, ml, item)
+		if err != nil {
+			return nil, err
+		}
+		result, _, err := ml.Subscriber.Update(ctx, &mailerlite.UpdateSubscriber{
+			ID:     id,
+			Email:  email,
+			Status: status,
+			Fields: fields,
+		})
+		return result, sdkclient.WrapError(err)
+	}
+
+	if cmdutil.IsBulk(c, items) {
+		updateRequest := func(ctx context.Context, item string) (sdkclient.BatchRequest, error) {
+			id, err := sdkclient.SubscriberID(ctx, ml, item)
+			if err != nil {
+				return sdkclient.BatchRequest{}, err
+			}
+			body, err := json.Marshal(&mailerlite.UpdateSubscriber{Email: email, Status: status, Fields: fields})
+			return sdkclient.BatchRequest{Method: http.MethodPut, Path: "/subscribers/" + id, Body: body}, err
+		}
+		summary, err := cmdutil.RunBulk(c, "Updating", items, func(ctx context.Context, item string) error {
+			_, err := update(ctx, item)
+			return err
+		}, updateRequest)
+		return cmdutil.PrintBulkSummary(c, summary, err,
+			fmt.Sprintf("Updated %d of %d subscribers.", summary.Succeeded, summary.Total))
+	}
+
+	result, err := update(c.Context(), items[0])
 	if err != nil {
-		return sdkclient.WrapError(err)
+		return err
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
-	output.Success("Subscriber " + args[0] + " updated successfully.")
+	output.Success("Subscriber " + items[0] + " updated successfully.")
 	return nil
 }
 
 // --- delete ---
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete <id>",
-	Short: "Delete a subscriber",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDelete,
+	Use:   "delete <subscriber>...",
+	Short: "Delete subscribers",
+	Long: `Delete one or more subscribers, given by ID or email address.
+
+Pass many subscribers with --file, one per line, or --file - to read them
+from stdin.`,
+	Example: `  mailerlite subscriber delete 123
+  mailerlite subscriber delete --file old-subscribers.txt --yes`,
+	Args: cmdutil.BulkArgs(0),
+	RunE: runDelete,
 }
 
 func runDelete(c *cobra.Command, args []string) error {
@@ -328,8 +378,18 @@ func runDelete(c *cobra.Command, args []string) error {
 		return err
 	}
 
+	items, err := cmdutil.BulkItems(c, args)
+	if err != nil {
+		return err
+	}
+	bulk := cmdutil.IsBulk(c, items)
+
 	if !cmdutil.YesFlag(c) && prompt.IsInteractive() {
-		ok, err := prompt.Confirm("Delete subscriber " + args[0] + "?")
+		label := "Delete subscriber " + items[0] + "?"
+		if bulk {
+			label = fmt.Sprintf("Delete %d subscribers?", len(items))
+		}
+		ok, err := prompt.Confirm(label)
 		if err != nil {
 			return err
 		}
@@ -338,24 +398,45 @@ func runDelete(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := context.Background()
-	_, err = ml.Subscriber.Delete(ctx, args[0])
-	if err != nil {
+	del := func(ctx context.Context, item string) error {
+		id, err := sdkclient.SubscriberID(ctx, ml, item)
+		if err != nil {
+			return err
+		}
+		_, err = ml.Subscriber.Delete(ctx, id)
 		return sdkclient.WrapError(err)
 	}
 
-	output.Success("Subscriber " + args[0] + " deleted successfully.")
+	if bulk {
+		delRequest := func(ctx context.Context, item string) (sdkclient.BatchRequest, error) {
+			id, err := sdkclient.SubscriberID(ctx, ml, item)
+			return sdkclient.BatchRequest{Method: http.MethodDelete, Path: "/subscribers/" + id}, err
+		}
+		summary, err := cmdutil.RunBulk(c, "Deleting", items, del, delRequest)
+		return cmdutil.PrintBulkSummary(c, summary, err,
+			fmt.Sprintf("Deleted %d of %d subscribers.", summary.Succeeded, summary.Total))
+	}
+
+	if err := del(c.Context(), items[0]); err != nil {
+		return err
+	}
+
+	output.Success("Subscriber " + items[0] + " deleted successfully.")
 	return nil
 }
 
 // --- forget ---
 
 var forgetCmd = &cobra.Command{
-	Use:   "forget <id>",
-	Short: "Forget a subscriber (GDPR)",
-	Long:  "Permanently forget a subscriber and all their data. This action cannot be undone.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runForget,
+	Use:   "forget <subscriber>...",
+	Short: "Forget subscribers (GDPR)",
+	Long: `Permanently forget one or more subscribers, given by ID or email address,
+and all their data. This action cannot be undone.
+
+Pass many subscribers with --file, one per line, or --file - to read them
+from stdin.`,
+	Args: cmdutil.BulkArgs(0),
+	RunE: runForget,
 }
 
 func runForget(c *cobra.Command, args []string) error {
@@ -364,8 +445,18 @@ func runForget(c *cobra.Command, args []string) error {
 		return err
 	}
 
+	items, err := cmdutil.BulkItems(c, args)
+	if err != nil {
+		return err
+	}
+	bulk := cmdutil.IsBulk(c, items)
+
 	if !cmdutil.YesFlag(c) && prompt.IsInteractive() {
-		ok, err := prompt.Confirm("Permanently forget subscriber " + args[0] + "? This cannot be undone.")
+		label := "Permanently forget subscriber " + items[0] + "? This cannot be undone."
+		if bulk {
+			label = fmt.Sprintf("Permanently forget %d subscribers? This cannot be undone.", len(items))
+		}
+		ok, err := prompt.Confirm(label)
 		if err != nil {
 			return err
 		}
@@ -374,13 +465,30 @@ func runForget(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := context.Background()
-	_, _, err = ml.Subscriber.Forget(ctx, args[0])
-	if err != nil {
+	forget := func(ctx context.Context, item string) error {
+		id, err := sdkclient.SubscriberID(ctx, ml, item)
+		if err != nil {
+			return err
+		}
+		_, _, err = ml.Subscriber.Forget(ctx, id)
 		return sdkclient.WrapError(err)
 	}
 
-	output.Success("Subscriber " + args[0] + " forgotten successfully.")
+	if bulk {
+		forgetRequest := func(ctx context.Context, item string) (sdkclient.BatchRequest, error) {
+			id, err := sdkclient.SubscriberID(ctx, ml, item)
+			return sdkclient.BatchRequest{Method: http.MethodPost, Path: "/subscribers/" + id + "/forget"}, err
+		}
+		summary, err := cmdutil.RunBulk(c, "Forgetting", items, forget, forgetRequest)
+		return cmdutil.PrintBulkSummary(c, summary, err,
+			fmt.Sprintf("Forgot %d of %d subscribers.", summary.Succeeded, summary.Total))
+	}
+
+	if err := forget(c.Context(), items[0]); err != nil {
+		return err
+	}
+
+	output.Success("Subscriber " + items[0] + " forgotten successfully.")
 	return nil
 }
 
@@ -1,10 +1,8 @@
 package timezone
 
 import (
-	"context"
-	"strconv"
-
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
 	"github.com/spf13/cobra"
@@ -34,27 +32,11 @@ func runList(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Timezone.List(ctx)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result.Data)
-	}
-
-	headers := []string{"ID", "NAME", "OFFSET"}
-	var rows [][]string
-
-	for _, tz := range result.Data {
-		rows = append(rows, []string{
-			tz.Id,
-			tz.Name,
-			strconv.Itoa(tz.Offset),
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Timezone, output.Items(result.Data))
 }
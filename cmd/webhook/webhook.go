@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/mailerlite/mailerlite-cli/internal/cmdutil"
+	"github.com/mailerlite/mailerlite-cli/internal/columns"
 	"github.com/mailerlite/mailerlite-cli/internal/output"
 	"github.com/mailerlite/mailerlite-cli/internal/prompt"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
@@ -73,9 +74,9 @@ func runList(c *cobra.Command, args []string) error {
 	limit, _ := c.Flags().GetInt("limit")
 	sort, _ := c.Flags().GetString("sort")
 
-	ctx := context.Background()
+	ctx := c.Context()
 
-	allWebhooks, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Webhook, bool, error) {
+	allWebhooks := sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Webhook, bool, error) {
 		opts := &mailerlite.ListWebhookOptions{
 			Page:  page,
 			Limit: perPage,
@@ -86,34 +87,9 @@ func runList(c *cobra.Command, args []string) error {
 			return nil, false, sdkclient.WrapError(err)
 		}
 		return root.Data, !root.Links.IsLastPage(), nil
-	}, limit)
-	if err != nil {
-		return err
-	}
-
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(allWebhooks)
-	}
+	}, cmdutil.PageOptions(c, limit))
 
-	headers := []string{"ID", "NAME", "URL", "ENABLED", "CREATED AT"}
-	var rows [][]string
-
-	for _, w := range allWebhooks {
-		enabled := "No"
-		if w.Enabled {
-			enabled = "Yes"
-		}
-		rows = append(rows, []string{
-			w.Id,
-			output.Truncate(w.Name, 40),
-			output.Truncate(w.Url, 50),
-			enabled,
-			w.CreatedAt,
-		})
-	}
-
-	output.Table(headers, rows)
-	return nil
+	return cmdutil.PrintList(c, columns.Webhook, allWebhooks)
 }
 
 // --- get ---
@@ -131,14 +107,14 @@ func runGet(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Webhook.Get(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	d := result.Data
@@ -197,7 +173,7 @@ func runCreate(c *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	opts := &mailerlite.CreateWebhookOptions{
 		Name:   name,
 		Url:    url,
@@ -209,8 +185,8 @@ func runCreate(c *cobra.Command, args []string) error {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Webhook created successfully. ID: " + result.Data.Id)
@@ -258,14 +234,14 @@ func runUpdate(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	result, _, err := ml.Webhook.Update(ctx, opts)
 	if err != nil {
 		return sdkclient.WrapError(err)
 	}
 
-	if cmdutil.JSONFlag(c) {
-		return output.JSON(result)
+	if cmdutil.StructuredOutput(c) {
+		return cmdutil.Print(c, result)
 	}
 
 	output.Success("Webhook " + args[0] + " updated successfully.")
@@ -297,7 +273,7 @@ func runDelete(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := context.Background()
+	ctx := c.Context()
 	_, err = ml.Webhook.Delete(ctx, args[0])
 	if err != nil {
 		return sdkclient.WrapError(err)
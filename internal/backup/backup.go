@@ -0,0 +1,414 @@
+// Package backup writes a snapshot of an account — its configuration,
+// content, subscribers and e-commerce catalog — to a directory or a gzipped
+// tarball, and restores the parts of a snapshot the API can recreate.
+//
+// A snapshot holds the API's own JSON for every item, one file per resource,
+// next to a backup.json that records the format version and item counts:
+//
+//	backup.json
+//	fields.json        [...]
+//	products.json      {"<shop id>": [...]}
+//	subscribers.jsonl  one subscriber per line
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+)
+
+// FormatVersion is the version of the snapshot layout written by Create.
+// Restore refuses snapshots with a newer version.
+const FormatVersion = 1
+
+// InfoFile is the name of the file that describes a snapshot.
+const InfoFile = "backup.json"
+
+// Resources are the resources a snapshot holds, in the order they are saved
+// and restored.
+var Resources = []string{
+	"fields", "groups", "segments", "webhooks", "forms", "campaigns", "automations",
+	"shops", "categories", "products", "subscribers",
+}
+
+// Info describes a snapshot.
+type Info struct {
+	Version    int            `json:"version"`
+	CreatedAt  time.Time      `json:"created_at"`
+	CLIVersion string         `json:"cli_version,omitempty"`
+	Counts     map[string]int `json:"counts"`
+}
+
+// Options control which resources Create saves and Restore restores.
+type Options struct {
+	// Resources to include; nil means all.
+	Resources []string
+	// Progress, if set, receives a line per resource as it is processed and
+	// Restore's progress bar for subscribers.
+	Progress io.Writer
+	// Concurrency is the number of subscribers Restore imports at once.
+	Concurrency int
+	// CLIVersion is recorded in the snapshot by Create.
+	CLIVersion string
+}
+
+// includes reports whether resource is to be processed. Categories and
+// products are kept per shop, so they bring in the shops.
+func (o Options) includes(resource string) bool {
+	if o.Resources == nil || slices.Contains(o.Resources, resource) {
+		return true
+	}
+	return resource == "shops" && (slices.Contains(o.Resources, "categories") || slices.Contains(o.Resources, "products"))
+}
+
+func (o Options) logf(format string, args ...interface{}) {
+	if o.Progress != nil {
+		fmt.Fprintf(o.Progress, format+"\n", args...) //nolint:errcheck
+	}
+}
+
+// ParseResources parses a comma-separated list of resource names.
+func ParseResources(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	var resources []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if !slices.Contains(Resources, v) {
+			return nil, fmt.Errorf("unknown resource %q: use one of %s", v, strings.Join(Resources, ", "))
+		}
+		resources = append(resources, v)
+	}
+	return resources, nil
+}
+
+// IsTarball reports whether path names a gzipped tarball rather than a
+// directory.
+func IsTarball(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+// client makes the raw API requests of a backup or restore.
+type client struct {
+	http   *http.Client
+	apiKey string
+}
+
+// all returns the items of every page of path.
+func (c client) all(ctx context.Context, path string) ([]json.RawMessage, error) {
+	items := []json.RawMessage{}
+	for item, err := range sdkclient.IterateRaw(ctx, c.http, c.apiKey, path, nil) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Create saves the account behind httpClient to path, a new directory or,
+// if IsTarball(path), a gzipped tarball. Snapshots hold subscribers'
+// personal data, so only the current user can read them.
+func Create(ctx context.Context, httpClient *http.Client, apiKey, path string, opts Options) (*Info, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%s already exists", path)
+	}
+
+	dir := path
+	if IsTarball(path) {
+		tmp, err := os.MkdirTemp("", "mailerlite-backup-")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(tmp) //nolint:errcheck
+		dir = tmp
+	} else if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	info, err := save(ctx, client{httpClient, apiKey}, dir, opts)
+	if err != nil {
+		os.RemoveAll(dir) //nolint:errcheck
+		return nil, err
+	}
+	if IsTarball(path) {
+		if err := writeTarball(dir, path); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// campaignStatuses are listed one by one, as the API lists only campaigns
+// that are ready to send unless a status is given.
+var campaignStatuses = []string{"draft", "ready", "sent"}
+
+// formTypes are the kinds of forms, which the API lists separately.
+var formTypes = []string{"popup", "embedded", "promotion"}
+
+func save(ctx context.Context, c client, dir string, opts Options) (*Info, error) {
+	info := &Info{
+		Version:    FormatVersion,
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		CLIVersion: opts.CLIVersion,
+		Counts:     map[string]int{},
+	}
+
+	var shops []json.RawMessage
+	for _, resource := range Resources {
+		if !opts.includes(resource) {
+			continue
+		}
+
+		var v interface{}
+		var n int
+		var err error
+		switch resource {
+		case "fields", "groups", "segments", "webhooks", "forms", "automations":
+			v, n, err = saved(c.list(ctx, resource))
+		case "campaigns":
+			v, n, err = saved(c.campaigns(ctx))
+		case "shops":
+			shops, err = c.list(ctx, resource)
+			v, n = shops, len(shops)
+		case "categories", "products":
+			var byShop map[string][]json.RawMessage
+			byShop, err = perShop(ctx, c, shops, resource)
+			v, n = byShop, countAll(byShop)
+		case "subscribers":
+			n, err = saveSubscribers(ctx, c, filepath.Join(dir, "subscribers.jsonl"))
+		}
+		if err == nil && v != nil {
+			err = writeJSON(filepath.Join(dir, resource+".json"), v)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to save %s: %w", resource, err)
+		}
+		info.Counts[resource] = n
+		opts.logf("Saved %d %s", n, resource)
+	}
+
+	if err := writeJSON(filepath.Join(dir, InfoFile), info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// Fetch returns every item of an account-wide resource (all but categories,
+// products and subscribers) as the API returns it.
+func Fetch(ctx context.Context, httpClient *http.Client, apiKey, resource string) ([]json.RawMessage, error) {
+	return client{httpClient, apiKey}.list(ctx, resource)
+}
+
+// list returns every item of an account-wide resource.
+func (c client) list(ctx context.Context, resource string) ([]json.RawMessage, error) {
+	switch resource {
+	case "fields", "groups", "segments", "webhooks", "automations":
+		return c.all(ctx, "/"+resource+"?limit=100")
+	case "forms":
+		return concat(ctx, c, formTypes, func(t string) string { return "/forms/" + t + "?limit=100" })
+	case "campaigns":
+		return concat(ctx, c, campaignStatuses, func(s string) string { return "/campaigns?limit=100&filter[status]=" + s })
+	case "shops":
+		return c.all(ctx, "/ecommerce/shops?limit=100")
+	}
+	return nil, fmt.Errorf("%s cannot be listed account-wide", resource)
+}
+
+// campaigns returns every campaign as GET /campaigns/{id} returns it, since
+// lists leave out the content of emails.
+func (c client) campaigns(ctx context.Context) ([]json.RawMessage, error) {
+	list, err := c.list(ctx, "campaigns")
+	if err != nil {
+		return nil, err
+	}
+	campaigns := make([]json.RawMessage, len(list))
+	for i, raw := range list {
+		var campaign struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &campaign); err != nil {
+			return nil, err
+		}
+		var res struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if _, err := sdkclient.DoRaw(ctx, c.http, c.apiKey, http.MethodGet, "/campaigns/"+campaign.ID, nil, &res); err != nil {
+			return nil, fmt.Errorf("campaign %s: %w", campaign.ID, err)
+		}
+		campaigns[i] = res.Data
+	}
+	return campaigns, nil
+}
+
+// saved returns a list of items as the value to save and its count.
+func saved(list []json.RawMessage, err error) (interface{}, int, error) {
+	return list, len(list), err
+}
+
+// concat returns the items of every page of path(v) for each of values.
+func concat(ctx context.Context, c client, values []string, path func(string) string) ([]json.RawMessage, error) {
+	items := []json.RawMessage{}
+	for _, v := range values {
+		more, err := c.all(ctx, path(v))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, more...)
+	}
+	return items, nil
+}
+
+// perShop returns the items of an e-commerce resource of each shop, keyed by
+// shop ID.
+func perShop(ctx context.Context, c client, shops []json.RawMessage, resource string) (map[string][]json.RawMessage, error) {
+	byShop := map[string][]json.RawMessage{}
+	for _, raw := range shops {
+		var shop struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &shop); err != nil {
+			return nil, err
+		}
+		items, err := c.all(ctx, "/ecommerce/shops/"+shop.ID+"/"+resource+"?limit=100")
+		if err != nil {
+			return nil, err
+		}
+		byShop[shop.ID] = items
+	}
+	return byShop, nil
+}
+
+func countAll(byShop map[string][]json.RawMessage) int {
+	n := 0
+	for _, items := range byShop {
+		n += len(items)
+	}
+	return n
+}
+
+// saveSubscribers streams every subscriber, with their groups, to path as
+// JSON lines.
+func saveSubscribers(ctx context.Context, c client, path string) (int, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	n := 0
+	var buf bytes.Buffer
+	for item, err := range sdkclient.IterateRaw(ctx, c.http, c.apiKey, "/subscribers?limit=1000&include=groups", nil) {
+		if err != nil {
+			return n, err
+		}
+		buf.Reset()
+		if err := json.Compact(&buf, item); err != nil {
+			return n, err
+		}
+		buf.WriteByte('\n')
+		if _, err := f.Write(buf.Bytes()); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, f.Close()
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Snapshot is a snapshot opened for reading.
+type Snapshot struct {
+	Info
+	dir     string
+	cleanup func()
+}
+
+// Open opens the snapshot at path, a directory or a gzipped tarball. The
+// snapshot must be closed to remove a tarball's extracted files.
+func Open(path string) (*Snapshot, error) {
+	s := &Snapshot{dir: path, cleanup: func() {}}
+	if IsTarball(path) {
+		dir, err := os.MkdirTemp("", "mailerlite-restore-")
+		if err != nil {
+			return nil, err
+		}
+		s.dir, s.cleanup = dir, func() { os.RemoveAll(dir) } //nolint:errcheck
+		if err := extractTarball(path, dir); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, InfoFile))
+	if err == nil {
+		err = json.Unmarshal(data, &s.Info)
+	}
+	if err != nil {
+		s.Close()
+		return nil, fmt.Errorf("%s is not a backup: %w", path, err)
+	}
+	if s.Version > FormatVersion {
+		s.Close()
+		return nil, fmt.Errorf("%s has format version %d; this version of the CLI reads up to %d", path, s.Version, FormatVersion)
+	}
+	return s, nil
+}
+
+// Close releases the snapshot.
+func (s *Snapshot) Close() {
+	s.cleanup()
+}
+
+// Has reports whether the snapshot holds resource.
+func (s *Snapshot) Has(resource string) bool {
+	_, ok := s.Counts[resource]
+	return ok
+}
+
+// Read decodes a resource's file into v.
+func (s *Snapshot) Read(resource string, v interface{}) error {
+	data, err := os.ReadFile(filepath.Join(s.dir, resource+".json"))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to read %s: %w", resource, err)
+	}
+	return nil
+}
+
+// Subscribers decodes each saved subscriber into a new T.
+func Subscribers[T any](s *Snapshot) ([]T, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, "subscribers.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	var subs []T
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var sub T
+		if err := dec.Decode(&sub); err != nil {
+			return nil, fmt.Errorf("failed to read subscribers: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
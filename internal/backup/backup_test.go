@@ -0,0 +1,99 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-cli/internal/mockapi"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+)
+
+// mockClient serves a mock API for the duration of the test and returns a
+// client for it.
+func mockClient(t *testing.T) client {
+	t.Helper()
+	srv := httptest.NewServer(mockapi.New(mockapi.Options{Subscribers: 20}))
+	t.Cleanup(srv.Close)
+	return client{&http.Client{Transport: &sdkclient.CLITransport{BaseURL: srv.URL + "/api"}}, "test"}
+}
+
+func TestBackupAndRestoreKeepCampaignContent(t *testing.T) {
+	ctx := context.Background()
+	const html = `<h1>Spring sale</h1><p>Everything is 20% off. <a href="{$unsubscribe}">Unsubscribe</a></p>`
+	const plain = "Spring sale: everything is 20% off."
+
+	source := mockClient(t)
+	body := map[string]interface{}{
+		"name": "Round trip", "type": "regular",
+		"emails": []interface{}{map[string]interface{}{
+			"subject": "20% off", "from_name": "Shop", "from": "shop@example.com",
+			"content": html, "plain_text": plain,
+		}},
+	}
+	if _, err := sdkclient.DoRaw(ctx, source.http, source.apiKey, http.MethodPost, "/campaigns", body, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	if _, err := Create(ctx, source.http, source.apiKey, path, Options{}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	snap, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer snap.Close()
+
+	target := mockClient(t)
+	ml := mailerlite.NewClient(target.apiKey)
+	ml.SetHttpClient(target.http)
+	outcomes, err := Restore(ctx, ml, target.http, target.apiKey, snap, Options{Resources: []string{"campaigns"}})
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if len(outcomes) != 1 || outcomes[0].Created != 1 || outcomes[0].Failed != 0 {
+		t.Fatalf("outcomes = %+v, want one campaign created", outcomes)
+	}
+
+	campaigns, err := target.list(ctx, "campaigns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var id string
+	for _, raw := range campaigns {
+		var c struct{ ID, Name string }
+		if err := json.Unmarshal(raw, &c); err != nil {
+			t.Fatal(err)
+		}
+		if c.Name == "Round trip" {
+			id = c.ID
+		}
+	}
+	if id == "" {
+		t.Fatal("the campaign was not restored")
+	}
+	var restored struct {
+		Data struct {
+			Emails []struct {
+				Subject   string `json:"subject"`
+				Content   string `json:"content"`
+				PlainText string `json:"plain_text"`
+			} `json:"emails"`
+		} `json:"data"`
+	}
+	if _, err := sdkclient.DoRaw(ctx, target.http, target.apiKey, http.MethodGet, "/campaigns/"+id, nil, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.Data.Emails) != 1 {
+		t.Fatalf("restored campaign has %d emails, want 1", len(restored.Data.Emails))
+	}
+	email := restored.Data.Emails[0]
+	if email.Subject != "20% off" || email.Content != html || email.PlainText != plain {
+		t.Errorf("restored email = %+v, want subject %q, content %q and plain text %q", email, "20% off", html, plain)
+	}
+}
@@ -0,0 +1,429 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/mailerlite/mailerlite-cli/internal/bulk"
+	"github.com/mailerlite/mailerlite-cli/internal/manifest"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+)
+
+// Outcome is the result of restoring one resource. Items that already exist
+// in the account (by name, or by email for subscribers) are left unchanged.
+type Outcome struct {
+	Resource string   `json:"resource"`
+	Total    int      `json:"total"`
+	Created  int      `json:"created"`
+	Existing int      `json:"existing"`
+	Skipped  int      `json:"skipped"`
+	Failed   int      `json:"failed"`
+	Note     string   `json:"note,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+func (o *Outcome) fail(name string, err error) {
+	o.Failed++
+	o.Errors = append(o.Errors, fmt.Sprintf("%s: %s", name, strings.Join(strings.Fields(err.Error()), " ")))
+}
+
+// notRestorable explains why the API cannot recreate a resource.
+var notRestorable = map[string]string{
+	"segments":    "segment filters are not available through the API",
+	"forms":       "forms cannot be created through the API",
+	"automations": "automations cannot be created through the API",
+}
+
+// restorer holds the state shared between the resources of a restore: the
+// target account's configuration and the IDs of restored shops.
+type restorer struct {
+	ml      *mailerlite.Client
+	c       client
+	snap    *Snapshot
+	opts    Options
+	current *manifest.Manifest
+	shopIDs map[string]string
+}
+
+// Restore recreates the snapshot's items in the account behind ml and
+// httpClient, resource by resource, as far as the API allows. Items are
+// matched by name and existing ones are not modified, so a restore can be
+// repeated. Subscribers keep their status, fields and groups.
+func Restore(ctx context.Context, ml *mailerlite.Client, httpClient *http.Client, apiKey string, snap *Snapshot, opts Options) ([]Outcome, error) {
+	r := &restorer{ml: ml, c: client{httpClient, apiKey}, snap: snap, opts: opts, shopIDs: map[string]string{}}
+	outcomes := []Outcome{}
+	for _, resource := range Resources {
+		if !opts.includes(resource) || !snap.Has(resource) {
+			continue
+		}
+		out := Outcome{Resource: resource, Total: snap.Counts[resource]}
+		var err error
+		switch resource {
+		case "fields", "groups", "webhooks":
+			err = r.restoreConfig(ctx, &out)
+		case "campaigns":
+			err = r.restoreCampaigns(ctx, &out)
+		case "shops":
+			err = r.restoreShops(ctx, &out)
+		case "categories", "products":
+			err = r.restoreCatalog(ctx, &out)
+		case "subscribers":
+			err = r.restoreSubscribers(ctx, &out)
+		default:
+			out.Skipped, out.Note = out.Total, notRestorable[resource]
+		}
+		if err != nil {
+			return outcomes, fmt.Errorf("failed to restore %s: %w", resource, err)
+		}
+		outcomes = append(outcomes, out)
+		opts.logf("Restored %s: %d created, %d existing, %d failed", resource, out.Created, out.Existing, out.Failed)
+	}
+	return outcomes, nil
+}
+
+// account returns the target account's configuration, fetching it again if
+// refresh is set.
+func (r *restorer) account(ctx context.Context, refresh bool) (*manifest.Manifest, error) {
+	if r.current == nil || refresh {
+		current, err := manifest.Fetch(ctx, r.ml)
+		if err != nil {
+			return nil, err
+		}
+		r.current = current
+	}
+	return r.current, nil
+}
+
+// restoreConfig creates the fields, groups or webhooks that are missing,
+// using the same plan as the apply command.
+func (r *restorer) restoreConfig(ctx context.Context, out *Outcome) error {
+	desired := &manifest.Manifest{}
+	var err error
+	switch out.Resource {
+	case "fields":
+		err = r.snap.Read("fields", &desired.Fields)
+	case "groups":
+		err = r.snap.Read("groups", &desired.Groups)
+	case "webhooks":
+		err = r.snap.Read("webhooks", &desired.Webhooks)
+	}
+	if err != nil {
+		return err
+	}
+	current, err := r.account(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	plan := manifest.NewPlan(desired, current, false)
+	out.Errors = append(out.Errors, plan.Problems...)
+	for _, change := range plan.Changes {
+		if change.Action != manifest.ActionCreate {
+			continue
+		}
+		if err := change.Apply(ctx, r.ml); err != nil {
+			out.fail(change.Name, err)
+			continue
+		}
+		out.Created++
+	}
+	out.Existing = out.Total - out.Created - out.Failed
+	r.current = nil
+	return ctx.Err()
+}
+
+// names returns the "name" of each item, mapped to its "id".
+func names(items []json.RawMessage) (map[string]string, error) {
+	m := make(map[string]string, len(items))
+	for _, raw := range items {
+		var item struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, err
+		}
+		if _, ok := m[item.Name]; !ok {
+			m[item.Name] = item.ID
+		}
+	}
+	return m, nil
+}
+
+// restoreCampaigns creates regular campaigns that are missing as drafts with
+// the saved email. Recipients are not restored, since group and segment IDs
+// differ between accounts.
+func (r *restorer) restoreCampaigns(ctx context.Context, out *Outcome) error {
+	out.Note = "regular campaigns are restored as drafts without recipients"
+	var campaigns []struct {
+		Name   string `json:"name"`
+		Type   string `json:"type"`
+		Emails []struct {
+			Subject   string `json:"subject"`
+			FromName  string `json:"from_name"`
+			From      string `json:"from"`
+			Content   string `json:"content"`
+			PlainText string `json:"plain_text"`
+		} `json:"emails"`
+	}
+	if err := r.snap.Read("campaigns", &campaigns); err != nil {
+		return err
+	}
+	existing, err := r.c.list(ctx, "campaigns")
+	if err != nil {
+		return err
+	}
+	have, err := names(existing)
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range campaigns {
+		switch {
+		case have[campaign.Name] != "":
+			out.Existing++
+			continue
+		case campaign.Type != "regular" || len(campaign.Emails) == 0:
+			out.Skipped++
+			continue
+		}
+		saved := campaign.Emails[0]
+		email := map[string]interface{}{"subject": saved.Subject, "from_name": saved.FromName, "from": saved.From}
+		if saved.Content != "" {
+			email["content"] = saved.Content
+		}
+		if saved.PlainText != "" {
+			email["plain_text"] = saved.PlainText
+		}
+		body := map[string]interface{}{"name": campaign.Name, "type": campaign.Type, "emails": []interface{}{email}}
+		if _, err := sdkclient.DoRaw(ctx, r.c.http, r.c.apiKey, http.MethodPost, "/campaigns", body, nil); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			out.fail(campaign.Name, err)
+			continue
+		}
+		out.Created++
+	}
+	return nil
+}
+
+// restoreShops creates the shops that are missing and records the ID of
+// each saved shop in the account.
+func (r *restorer) restoreShops(ctx context.Context, out *Outcome) error {
+	var shops []map[string]interface{}
+	if err := r.snap.Read("shops", &shops); err != nil {
+		return err
+	}
+	existing, err := r.c.list(ctx, "shops")
+	if err != nil {
+		return err
+	}
+	have, err := names(existing)
+	if err != nil {
+		return err
+	}
+
+	for _, shop := range shops {
+		oldID, name := fmt.Sprint(shop["id"]), fmt.Sprint(shop["name"])
+		if id := have[name]; id != "" {
+			r.shopIDs[oldID] = id
+			out.Existing++
+			continue
+		}
+		var created struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		body := pick(shop, "name", "url", "currency")
+		if _, err := sdkclient.DoRaw(ctx, r.c.http, r.c.apiKey, http.MethodPost, "/ecommerce/shops", body, &created); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			out.fail(name, err)
+			continue
+		}
+		r.shopIDs[oldID] = created.Data.ID
+		out.Created++
+	}
+	return nil
+}
+
+// catalogFields are the properties sent when creating categories and
+// products.
+var catalogFields = map[string][]string{
+	"categories": {"name"},
+	"products":   {"name", "price", "url", "image_url", "description", "quantity"},
+}
+
+// restoreCatalog creates the categories or products that are missing in each
+// restored shop.
+func (r *restorer) restoreCatalog(ctx context.Context, out *Outcome) error {
+	var byShop map[string][]map[string]interface{}
+	if err := r.snap.Read(out.Resource, &byShop); err != nil {
+		return err
+	}
+	for oldShop, items := range byShop {
+		shopID := r.shopIDs[oldShop]
+		if shopID == "" {
+			out.Skipped += len(items)
+			continue
+		}
+		path := "/ecommerce/shops/" + shopID + "/" + out.Resource
+		existing, err := r.c.all(ctx, path+"?limit=100")
+		if err != nil {
+			return err
+		}
+		have, err := names(existing)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			name := fmt.Sprint(item["name"])
+			if have[name] != "" {
+				out.Existing++
+				continue
+			}
+			if _, err := sdkclient.DoRaw(ctx, r.c.http, r.c.apiKey, http.MethodPost, path, pick(item, catalogFields[out.Resource]...), nil); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				out.fail(name, err)
+				continue
+			}
+			out.Created++
+		}
+	}
+	if out.Skipped > 0 {
+		out.Note = "items of shops that were not restored are skipped"
+	}
+	return nil
+}
+
+// pick returns the values of keys in item that are neither null nor empty.
+func pick(item map[string]interface{}, keys ...string) map[string]interface{} {
+	body := map[string]interface{}{}
+	for _, k := range keys {
+		if v, ok := item[k]; ok && v != nil && v != "" {
+			body[k] = v
+		}
+	}
+	return body
+}
+
+// savedSubscriber is the part of a saved subscriber that is restored.
+type savedSubscriber struct {
+	Email  string                 `json:"email"`
+	Status string                 `json:"status"`
+	Fields map[string]interface{} `json:"fields"`
+	Groups []struct {
+		ID string `json:"id"`
+	} `json:"groups"`
+}
+
+// restoreSubscribers upserts every subscriber with their status, fields and
+// groups. Field keys and group IDs are translated through the names of the
+// saved fields and groups; those missing in the account are dropped.
+func (r *restorer) restoreSubscribers(ctx context.Context, out *Outcome) error {
+	subs, err := Subscribers[savedSubscriber](r.snap)
+	if err != nil {
+		return err
+	}
+	groupIDs, fieldKeys, err := r.translations(ctx)
+	if err != nil {
+		return err
+	}
+
+	bodies := make(map[string]map[string]interface{}, len(subs))
+	emails := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		fields := map[string]interface{}{}
+		for key, v := range sub.Fields {
+			if newKey := fieldKeys[key]; newKey != "" && v != nil {
+				fields[newKey] = v
+			}
+		}
+		groups := []string{}
+		for _, g := range sub.Groups {
+			if id := groupIDs[g.ID]; id != "" {
+				groups = append(groups, id)
+			}
+		}
+		body := map[string]interface{}{"email": sub.Email, "fields": fields, "groups": groups}
+		if sub.Status != "" {
+			body["status"] = sub.Status
+		}
+		if _, dup := bodies[sub.Email]; !dup {
+			emails = append(emails, sub.Email)
+		}
+		bodies[sub.Email] = body
+	}
+
+	var updated atomic.Int64
+	summary, err := bulk.Run(ctx, emails, bulk.Options{Concurrency: r.opts.Concurrency, Progress: r.opts.Progress, Label: "Restoring subscribers"},
+		func(ctx context.Context, email string) error {
+			resp, err := sdkclient.DoRaw(ctx, r.c.http, r.c.apiKey, http.MethodPost, "/subscribers", bodies[email], nil)
+			if err == nil && resp.StatusCode == http.StatusOK {
+				updated.Add(1)
+			}
+			return err
+		})
+	out.Existing = int(updated.Load())
+	out.Created = summary.Succeeded - out.Existing
+	out.Skipped = summary.Skipped
+	for _, f := range summary.Failures() {
+		out.Failed++
+		out.Errors = append(out.Errors, f.Item+": "+f.Error)
+	}
+	return err
+}
+
+// translations maps saved group IDs to the IDs of the groups with the same
+// names in the account, and saved field keys to account field keys.
+func (r *restorer) translations(ctx context.Context) (groupIDs, fieldKeys map[string]string, err error) {
+	current, err := r.account(ctx, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groupIDs = map[string]string{}
+	if r.snap.Has("groups") {
+		var saved []manifest.Group
+		if err := r.snap.Read("groups", &saved); err != nil {
+			return nil, nil, err
+		}
+		byName := map[string]string{}
+		for _, g := range current.Groups {
+			byName[g.Name] = g.ID
+		}
+		for _, g := range saved {
+			groupIDs[g.ID] = byName[g.Name]
+		}
+	}
+
+	fieldKeys = map[string]string{}
+	for _, f := range current.Fields {
+		fieldKeys[f.Key] = f.Key
+	}
+	if r.snap.Has("fields") {
+		var saved []manifest.Field
+		if err := r.snap.Read("fields", &saved); err != nil {
+			return nil, nil, err
+		}
+		byName := map[string]string{}
+		for _, f := range current.Fields {
+			byName[f.Name] = f.Key
+		}
+		for _, f := range saved {
+			fieldKeys[f.Key] = byName[f.Name]
+		}
+	}
+	return groupIDs, fieldKeys, nil
+}
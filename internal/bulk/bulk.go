@@ -0,0 +1,230 @@
+// Package bulk runs one operation per input item (a subscriber ID, an email
+// address, a campaign ID, ...) with bounded concurrency, one item or one
+// batch of items at a time, and collects the outcome of each item into a
+// summary.
+package bulk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency is the number of items processed at once by default.
+const DefaultConcurrency = 4
+
+// Result statuses.
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+)
+
+// Options control how Run processes items.
+type Options struct {
+	Concurrency int
+	// Progress, if set, receives a progress bar that is redrawn in place.
+	Progress io.Writer
+	// Label is shown in front of the progress bar.
+	Label string
+}
+
+// Result is the outcome of one item.
+type Result struct {
+	Item   string `json:"item"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Summary is the report of a bulk run. Items that were not attempted because
+// the run was cancelled are counted as skipped.
+type Summary struct {
+	Total     int      `json:"total"`
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Skipped   int      `json:"skipped,omitempty"`
+	Results   []Result `json:"results"`
+}
+
+// Failures returns the results of the items that failed.
+func (s Summary) Failures() []Result {
+	var failed []Result
+	for _, r := range s.Results {
+		if r.Status == StatusFailed {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// Func performs the operation for one item.
+type Func func(ctx context.Context, item string) error
+
+// BatchFunc performs the operation for several items at once and returns
+// one error (or nil) per item.
+type BatchFunc func(ctx context.Context, items []string) []error
+
+// Run calls fn for every item, at most opts.Concurrency at a time. Results
+// are reported in input order. If ctx is cancelled, no new items are started
+// and ctx's error is returned along with the summary of what did run.
+func Run(ctx context.Context, items []string, opts Options, fn Func) (Summary, error) {
+	return RunBatches(ctx, items, 1, opts, func(ctx context.Context, batch []string) []error {
+		return []error{fn(ctx, batch[0])}
+	})
+}
+
+// RunBatches is like Run, but passes fn up to size items at a time.
+func RunBatches(ctx context.Context, items []string, size int, opts Options, fn BatchFunc) (Summary, error) {
+	size = max(1, size)
+	results := make([]Result, len(items))
+	bar := newProgressBar(opts.Progress, opts.Label, len(items))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	for range max(1, opts.Concurrency) {
+		wg.Go(func() {
+			for start := range jobs {
+				batch := items[start:min(start+size, len(items))]
+				errs := fn(ctx, batch)
+				mu.Lock()
+				for i, item := range batch {
+					err := errs[i]
+					if err != nil && ctx.Err() != nil {
+						// Interrupted mid-flight: leave it for a re-run.
+						continue
+					}
+					res := Result{Item: item, Status: StatusOK}
+					if err != nil {
+						res.Status = StatusFailed
+						res.Error = strings.ReplaceAll(err.Error(), "\n", " ")
+					}
+					results[start+i] = res
+					bar.add(err == nil)
+				}
+				mu.Unlock()
+			}
+		})
+	}
+
+feed:
+	for start := 0; start < len(items); start += size {
+		select {
+		case jobs <- start:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	bar.finish()
+
+	summary := Summary{Total: len(items), Results: []Result{}}
+	for _, res := range results {
+		switch res.Status {
+		case StatusOK:
+			summary.Succeeded++
+		case StatusFailed:
+			summary.Failed++
+		default:
+			summary.Skipped++
+			continue
+		}
+		summary.Results = append(summary.Results, res)
+	}
+	return summary, ctx.Err()
+}
+
+// ReadItems reads one item per line from path, or from stdin if path is
+// "-". Blank lines and lines starting with # are ignored, and duplicates are
+// dropped.
+func ReadItems(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close() //nolint:errcheck
+		r = f
+	}
+
+	var items []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		item := strings.TrimSpace(scanner.Text())
+		if item == "" || strings.HasPrefix(item, "#") || seen[item] {
+			continue
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read items: %w", err)
+	}
+	return items, nil
+}
+
+// progressBar draws "label [=====     ] 120/500 (2 failed)" on a terminal,
+// redrawing at most every progressInterval. A nil *progressBar draws nothing.
+type progressBar struct {
+	w      io.Writer
+	label  string
+	total  int
+	done   int
+	failed int
+	drawn  time.Time
+}
+
+const (
+	progressWidth    = 30
+	progressInterval = 100 * time.Millisecond
+)
+
+func newProgressBar(w io.Writer, label string, total int) *progressBar {
+	if w == nil || total == 0 {
+		return nil
+	}
+	p := &progressBar{w: w, label: label, total: total}
+	p.draw()
+	return p
+}
+
+func (p *progressBar) add(ok bool) {
+	if p == nil {
+		return
+	}
+	p.done++
+	if !ok {
+		p.failed++
+	}
+	if time.Since(p.drawn) >= progressInterval {
+		p.draw()
+	}
+}
+
+func (p *progressBar) finish() {
+	if p == nil {
+		return
+	}
+	p.draw()
+	fmt.Fprintln(p.w) //nolint:errcheck
+}
+
+func (p *progressBar) draw() {
+	filled := progressWidth * p.done / p.total
+	line := fmt.Sprintf("[%s%s] %d/%d", strings.Repeat("=", filled), strings.Repeat(" ", progressWidth-filled), p.done, p.total)
+	if p.label != "" {
+		line = p.label + " " + line
+	}
+	if p.failed > 0 {
+		line += fmt.Sprintf(" (%d failed)", p.failed)
+	}
+	fmt.Fprint(p.w, "\r"+line) //nolint:errcheck
+	p.drawn = time.Now()
+}
@@ -0,0 +1,24 @@
+package cmdutil
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// OpenBrowser opens url, or a local file path, in the default browser. It
+// does not wait for the browser and ignores failures, so callers should also
+// print what is being opened.
+func OpenBrowser(url string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "linux":
+		cmd = exec.Command("xdg-open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	}
+	if cmd != nil {
+		_ = cmd.Start()
+	}
+}
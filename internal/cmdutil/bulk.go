@@ -0,0 +1,145 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mailerlite/mailerlite-cli/internal/bulk"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/spf13/cobra"
+)
+
+// AddBulkFlags adds the --file, --concurrency and --batch flags of a command
+// that accepts many items, e.g. subscriber IDs or emails.
+func AddBulkFlags(cmd *cobra.Command, items string) {
+	cmd.Flags().String("file", "", "read "+items+" from this file, one per line (- for stdin)")
+	cmd.Flags().Int("concurrency", bulk.DefaultConcurrency, "number of "+items+" (or batches) to process at once")
+	cmd.Flags().Bool("batch", false, fmt.Sprintf("send requests through the batch endpoint, %d per call", sdkclient.MaxBatchSize))
+}
+
+// BulkArgs accepts n leading arguments followed by at least one item, or no
+// items when --file is given.
+func BulkArgs(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) < n {
+			return fmt.Errorf("accepts at least %d arg(s), received %d", n, len(args))
+		}
+		if len(args) == n && !cmd.Flags().Changed("file") {
+			return fmt.Errorf("missing arguments: pass one or more IDs or use --file")
+		}
+		return nil
+	}
+}
+
+// BulkItems returns the items of a bulk command: the given arguments
+// followed by the lines of --file.
+func BulkItems(cmd *cobra.Command, args []string) ([]string, error) {
+	items := append([]string{}, args...)
+	if path, _ := cmd.Flags().GetString("file"); path != "" {
+		more, err := bulk.ReadItems(path)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, more...)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no items to process")
+	}
+	return items, nil
+}
+
+// IsBulk reports whether a bulk command was given more than a single item.
+func IsBulk(cmd *cobra.Command, items []string) bool {
+	return len(items) > 1 || cmd.Flags().Changed("file")
+}
+
+// BatchRequestFunc builds the API request that performs a bulk operation for
+// one item, to be sent through the batch endpoint.
+type BatchRequestFunc func(ctx context.Context, item string) (sdkclient.BatchRequest, error)
+
+// RunBulk runs fn for every item with the command's --concurrency, or with
+// --batch sends the requests built by request through the batch endpoint
+// instead. A progress bar labelled label is drawn on stderr when it is a
+// terminal and not used for the debug log.
+func RunBulk(cmd *cobra.Command, label string, items []string, fn bulk.Func, request BatchRequestFunc) (bulk.Summary, error) {
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	opts := bulk.Options{Concurrency: concurrency, Label: label, Progress: ProgressWriter(cmd)}
+
+	if batch, _ := cmd.Flags().GetBool("batch"); !batch {
+		return bulk.Run(cmd.Context(), items, opts, fn)
+	}
+
+	httpClient, token, err := RawHTTPClient(cmd)
+	if err != nil {
+		return bulk.Summary{}, err
+	}
+	return bulk.RunBatches(cmd.Context(), items, sdkclient.MaxBatchSize, opts, func(ctx context.Context, batch []string) []error {
+		errs := make([]error, len(batch))
+		var reqs []sdkclient.BatchRequest
+		var sent []int
+		for i, item := range batch {
+			req, err := request(ctx, item)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			reqs = append(reqs, req)
+			sent = append(sent, i)
+		}
+
+		responses, err := sdkclient.Batch(ctx, httpClient, token, reqs)
+		for j, i := range sent {
+			if j < len(responses) {
+				errs[i] = responses[j].Err()
+			} else {
+				errs[i] = err
+			}
+		}
+		return errs
+	})
+}
+
+// PrintBulkSummary reports the outcome of RunBulk: the summary itself for
+// structured output, otherwise each failure followed by msg. The returned
+// error is non-nil if any item failed or the run was interrupted.
+func PrintBulkSummary(cmd *cobra.Command, summary bulk.Summary, runErr error, msg string) error {
+	if StructuredOutput(cmd) {
+		if err := Print(cmd, summary); err != nil {
+			return err
+		}
+		return runErr
+	}
+
+	for _, r := range summary.Failures() {
+		output.Errorf("%s: %s", r.Item, r.Error)
+	}
+	if runErr != nil {
+		output.Errorf("%s %d not processed.", msg, summary.Skipped)
+		return runErr
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%s %d failed", msg, summary.Failed)
+	}
+	output.Success(msg)
+	return nil
+}
+
+// ProgressWriter returns stderr if progress can be drawn on it, i.e. it is
+// a terminal and not used for the debug log, and nil otherwise.
+func ProgressWriter(cmd *cobra.Command) io.Writer {
+	if isTerminal(os.Stderr) && !VerboseFlag(cmd) {
+		return os.Stderr
+	}
+	return nil
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
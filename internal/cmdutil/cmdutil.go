@@ -2,12 +2,19 @@ package cmdutil
 
 import (
 	"fmt"
+	"io"
+	"iter"
+	"log/slog"
 	"net/http"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/mailerlite/mailerlite-cli/internal/config"
+	"github.com/mailerlite/mailerlite-cli/internal/logging"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-cli/internal/query"
 	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
 	"github.com/mailerlite/mailerlite-go"
 	"github.com/spf13/cobra"
@@ -25,70 +32,265 @@ func VerboseFlag(cmd *cobra.Command) bool {
 	return v
 }
 
-// JSONFlag returns the --json persistent flag value.
-func JSONFlag(cmd *cobra.Command) bool {
-	v, _ := cmd.Root().PersistentFlags().GetBool("json")
+var (
+	loggerOnce sync.Once
+	logger     *slog.Logger
+	loggerErr  error
+)
+
+// Logger returns the debug logger selected with --verbose, --log-file and
+// --log-format, or nil if logging is off. Logs go to stderr, or to the log
+// file when one is given, so they never mix with command output.
+func Logger(cmd *cobra.Command) (*slog.Logger, error) {
+	loggerOnce.Do(func() {
+		flags := cmd.Root().PersistentFlags()
+		file, _ := flags.GetString("log-file")
+		format, _ := flags.GetString("log-format")
+		if _, loggerErr = logging.New(io.Discard, format); loggerErr != nil || (file == "" && !VerboseFlag(cmd)) {
+			return
+		}
+
+		var w io.Writer = os.Stderr
+		if file != "" {
+			f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+			if err != nil {
+				loggerErr = fmt.Errorf("failed to open log file: %w", err)
+				return
+			}
+			w = f
+		}
+		logger, loggerErr = logging.New(w, format)
+	})
+	return logger, loggerErr
+}
+
+var (
+	limiterOnce sync.Once
+	limiter     *sdkclient.RateLimiter
+)
+
+// RateLimiter returns the limiter shared by every HTTP client of the process,
+// allowing --requests-per-minute requests, or nil if that is 0.
+func RateLimiter(cmd *cobra.Command) *sdkclient.RateLimiter {
+	limiterOnce.Do(func() {
+		if perMinute, _ := cmd.Root().PersistentFlags().GetInt("requests-per-minute"); perMinute > 0 {
+			limiter = sdkclient.NewRateLimiter(perMinute)
+		}
+	})
+	return limiter
+}
+
+// RetryPolicy returns the retry policy selected with --max-retries and
+// --max-retry-wait.
+func RetryPolicy(cmd *cobra.Command) sdkclient.RetryPolicy {
+	flags := cmd.Root().PersistentFlags()
+	policy := sdkclient.DefaultRetryPolicy
+	if flags.Lookup("max-retries") != nil {
+		policy.MaxRetries, _ = flags.GetInt("max-retries")
+		policy.MaxWait, _ = flags.GetDuration("max-retry-wait")
+	}
+	return policy
+}
+
+// RecordFlag returns the --record persistent flag value.
+func RecordFlag(cmd *cobra.Command) string {
+	v, _ := cmd.Root().PersistentFlags().GetString("record")
 	return v
 }
 
+// ReplayFlag returns the --replay persistent flag value.
+func ReplayFlag(cmd *cobra.Command) string {
+	v, _ := cmd.Root().PersistentFlags().GetString("replay")
+	return v
+}
+
+// JSONFlag reports whether JSON output was requested, either with --json or
+// with --output json/jsonl.
+func JSONFlag(cmd *cobra.Command) bool {
+	f := OutputFormat(cmd)
+	return f.Name == output.FormatJSON || f.Name == output.FormatJSONL
+}
+
+// ParseOutputFlags validates the --output, --json and --query persistent
+// flags and returns the selected format. --json is shorthand for --output
+// json, and --query without --output implies JSON.
+func ParseOutputFlags(cmd *cobra.Command) (output.Format, error) {
+	flags := cmd.Root().PersistentFlags()
+	value, _ := flags.GetString("output")
+	jsonFlag, _ := flags.GetBool("json")
+	expr, _ := flags.GetString("query")
+
+	f, err := output.ParseFormat(value)
+	if err != nil {
+		return output.Format{}, err
+	}
+	if jsonFlag {
+		if flags.Changed("output") && f.Name != output.FormatJSON {
+			return output.Format{}, fmt.Errorf("--json cannot be combined with --output %s", f.Name)
+		}
+		f = output.Format{Name: output.FormatJSON}
+	}
+	f.Columns, _ = flags.GetStringSlice("columns")
+	f.SortBy, _ = flags.GetString("sort-by")
+	f.NoHeaders, _ = flags.GetBool("no-headers")
+	if expr != "" {
+		if f.IsTable() {
+			if flags.Changed("output") {
+				return output.Format{}, fmt.Errorf("--query cannot be used with table output")
+			}
+			f = output.Format{Name: output.FormatJSON}
+		}
+		if f.Query, err = query.Compile(expr); err != nil {
+			return output.Format{}, err
+		}
+	}
+	return f, nil
+}
+
+// OutputFormat returns the format selected with --output (or --json). The
+// flags are validated before any command runs, so errors are ignored here.
+func OutputFormat(cmd *cobra.Command) output.Format {
+	f, _ := ParseOutputFlags(cmd)
+	return f
+}
+
+// StructuredOutput reports whether a machine-readable format was selected,
+// i.e. anything other than the default table output.
+func StructuredOutput(cmd *cobra.Command) bool {
+	return !OutputFormat(cmd).IsTable()
+}
+
+// Print renders a single value in the selected output format.
+func Print(cmd *cobra.Command, v interface{}) error {
+	return output.Print(OutputFormat(cmd), v)
+}
+
+// PrintList renders a stream of items in the selected output format, using
+// the resource's column set for table, CSV and TSV output.
+func PrintList[T any](cmd *cobra.Command, columns output.ColumnSet[T], items iter.Seq2[T, error]) error {
+	return output.List(OutputFormat(cmd), columns, items)
+}
+
 // YesFlag returns the --yes persistent flag value.
 func YesFlag(cmd *cobra.Command) bool {
 	v, _ := cmd.Root().PersistentFlags().GetBool("yes")
 	return v
 }
 
+// PageOptions builds pagination options for a list command from its --limit
+// value and the global --page-size flag. Pages are prefetched whenever more
+// than one page is expected.
+func PageOptions(cmd *cobra.Command, limit int) sdkclient.PageOptions {
+	size, _ := cmd.Root().PersistentFlags().GetInt("page-size")
+	if size <= 0 {
+		size = sdkclient.DefaultPageSize
+	}
+	return sdkclient.PageOptions{
+		Limit:    limit,
+		PageSize: size,
+		Prefetch: limit == 0 || limit > size,
+	}
+}
+
+// AnnotationRunsUntilStopped marks a command, such as a server, that runs
+// until it is stopped with Ctrl-C, so being interrupted is how it succeeds.
+const AnnotationRunsUntilStopped = "runs-until-stopped"
+
+// RunsUntilStopped reports whether cmd is marked with
+// AnnotationRunsUntilStopped.
+func RunsUntilStopped(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[AnnotationRunsUntilStopped]
+	return ok
+}
+
 // SetVersion configures the SDK client user-agent with the CLI version.
 func SetVersion(v string) {
 	sdkclient.SetUserAgent("mailerlite-cli/" + v)
 }
 
-// NewSDKClient creates a mailerlite-go SDK client with CLI-specific behavior
-// injected via a custom HTTP transport (retry, verbose, user-agent, base URL).
-// Returns both the SDK client and the transport (needed for error body access).
+// requestTimeout bounds the wait for each API response. Retries and rate
+// limiting are bounded by the retry policy instead.
+const requestTimeout = 30 * time.Second
+
+// NewSDKClient creates a mailerlite-go SDK client that sends its requests
+// through the same HTTP client as RawHTTPClient.
 func NewSDKClient(cmd *cobra.Command) (*mailerlite.Client, error) {
-	token, err := config.GetToken(ProfileFlag(cmd))
+	return NewSDKClientForProfile(cmd, ProfileFlag(cmd))
+}
+
+// NewSDKClientForProfile is like NewSDKClient but uses the given profile
+// instead of --profile, for commands that work with several accounts.
+func NewSDKClientForProfile(cmd *cobra.Command, profile string) (*mailerlite.Client, error) {
+	httpClient, token, err := RawHTTPClientForProfile(cmd, profile)
 	if err != nil {
 		return nil, err
 	}
 
-	transport := &sdkclient.CLITransport{
-		Base:      http.DefaultTransport,
-		Verbose:   VerboseFlag(cmd),
-		AccountID: config.GetAccountID(ProfileFlag(cmd)),
-	}
-
-	if base := os.Getenv("MAILERLITE_API_BASE_URL"); base != "" {
-		transport.BaseURL = base
-	}
-
 	ml := mailerlite.NewClient(token)
-	ml.SetHttpClient(&http.Client{
-		Timeout:   30 * time.Second,
-		Transport: transport,
-	})
-
+	ml.SetHttpClient(httpClient)
 	return ml, nil
 }
 
-// RawHTTPClient creates an *http.Client and API key for raw HTTP e-commerce calls.
+// RawHTTPClient returns the HTTP client and API token for the selected
+// profile, for raw HTTP calls with sdkclient.DoRaw. Replaying recorded
+// responses needs no token.
 func RawHTTPClient(cmd *cobra.Command) (*http.Client, string, error) {
-	token, err := config.GetToken(ProfileFlag(cmd))
+	return RawHTTPClientForProfile(cmd, ProfileFlag(cmd))
+}
+
+// RawHTTPClientForProfile is like RawHTTPClient but uses the given profile
+// instead of --profile.
+func RawHTTPClientForProfile(cmd *cobra.Command, profile string) (*http.Client, string, error) {
+	token, err := config.GetToken(profile)
+	if err != nil {
+		if ReplayFlag(cmd) == "" {
+			return nil, "", err
+		}
+		token = "replay"
+	}
+
+	httpClient, err := NewHTTPClient(cmd, profile)
 	if err != nil {
 		return nil, "", err
 	}
+	return httpClient, token, nil
+}
+
+// NewHTTPClient creates the HTTP client every API call of the given profile
+// goes through, with CLI-specific behavior injected via a custom transport
+// (rate limiting, retry, debug logging, user-agent, account header, base URL, and recording or
+// replaying with --record and --replay).
+func NewHTTPClient(cmd *cobra.Command, profile string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = requestTimeout
 
-	transport := &sdkclient.CLITransport{
-		Base:      http.DefaultTransport,
-		Verbose:   VerboseFlag(cmd),
-		AccountID: config.GetAccountID(ProfileFlag(cmd)),
+	var base http.RoundTripper = transport
+	if dir := RecordFlag(cmd); dir != "" {
+		base = &sdkclient.Recorder{Base: base, Dir: dir}
+	}
+	if dir := ReplayFlag(cmd); dir != "" {
+		replayer, err := sdkclient.NewReplayer(dir)
+		if err != nil {
+			return nil, err
+		}
+		base = replayer
 	}
 
-	httpClient := &http.Client{
-		Timeout:   30 * time.Second,
-		Transport: transport,
+	log, err := Logger(cmd)
+	if err != nil {
+		return nil, err
 	}
 
-	return httpClient, token, nil
+	return &http.Client{
+		Transport: &sdkclient.CLITransport{
+			Base:      base,
+			Logger:    log,
+			BaseURL:   config.GetBaseURL(profile),
+			AccountID: config.GetAccountID(profile),
+			Limiter:   RateLimiter(cmd),
+			Retry:     RetryPolicy(cmd),
+		},
+	}, nil
 }
 
 // ParseDate accepts a date string in YYYY-MM-DD format or a raw unix
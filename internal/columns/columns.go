@@ -0,0 +1,236 @@
+// Package columns is the registry of list columns for each resource. List
+// commands render through these sets so that --columns, --sort-by and
+// --no-headers behave the same everywhere.
+package columns
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+	"github.com/mailerlite/mailerlite-go"
+)
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
+
+func float(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Subscriber holds the columns for subscribers.
+var Subscriber = output.NewColumnSet(
+	[]string{"email", "status", "source", "opens_count", "clicks_count", "subscribed_at"},
+	[]output.Column[mailerlite.Subscriber]{
+		{Name: "id", Header: "ID", Value: func(s mailerlite.Subscriber) string { return s.ID }},
+		{Name: "email", Header: "EMAIL", Value: func(s mailerlite.Subscriber) string { return s.Email }},
+		{Name: "status", Header: "STATUS", Value: func(s mailerlite.Subscriber) string { return s.Status }},
+		{Name: "source", Header: "SOURCE", Value: func(s mailerlite.Subscriber) string { return s.Source }},
+		{Name: "sent", Header: "SENT", Value: func(s mailerlite.Subscriber) string { return itoa(s.Sent) }},
+		{Name: "opens_count", Header: "OPENS", Value: func(s mailerlite.Subscriber) string { return itoa(s.OpensCount) }},
+		{Name: "clicks_count", Header: "CLICKS", Value: func(s mailerlite.Subscriber) string { return itoa(s.ClicksCount) }},
+		{Name: "open_rate", Header: "OPEN RATE", Value: func(s mailerlite.Subscriber) string { return float(s.OpenRate) }},
+		{Name: "click_rate", Header: "CLICK RATE", Value: func(s mailerlite.Subscriber) string { return float(s.ClickRate) }},
+		{Name: "ip_address", Header: "IP ADDRESS", Value: func(s mailerlite.Subscriber) string { return stringValue(s.IPAddress) }},
+		{Name: "groups", Header: "GROUPS", Value: func(s mailerlite.Subscriber) string {
+			names := make([]string, len(s.Groups))
+			for i, g := range s.Groups {
+				names[i] = g.Name
+			}
+			return strings.Join(names, ", ")
+		}},
+		{Name: "subscribed_at", Header: "SUBSCRIBED AT", Value: func(s mailerlite.Subscriber) string { return s.SubscribedAt }},
+		{Name: "unsubscribed_at", Header: "UNSUBSCRIBED AT", Value: func(s mailerlite.Subscriber) string { return stringValue(s.UnsubscribedAt) }},
+		{Name: "created_at", Header: "CREATED AT", Value: func(s mailerlite.Subscriber) string { return s.CreatedAt }},
+		{Name: "updated_at", Header: "UPDATED AT", Value: func(s mailerlite.Subscriber) string { return s.UpdatedAt }},
+		{Name: "opted_in_at", Header: "OPTED IN AT", Value: func(s mailerlite.Subscriber) string { return s.OptedInAt }},
+		{Name: "optin_ip", Header: "OPTIN IP", Value: func(s mailerlite.Subscriber) string { return s.OptinIP }},
+	},
+)
+
+// Campaign holds the columns for campaigns.
+var Campaign = output.NewColumnSet(
+	[]string{"id", "name", "type", "status", "stats.sent", "stats.opens_count", "stats.clicks_count"},
+	[]output.Column[mailerlite.Campaign]{
+		{Name: "id", Header: "ID", Value: func(c mailerlite.Campaign) string { return c.ID }},
+		{Name: "name", Header: "NAME", Value: func(c mailerlite.Campaign) string { return c.Name }},
+		{Name: "type", Header: "TYPE", Value: func(c mailerlite.Campaign) string { return c.Type }},
+		{Name: "status", Header: "STATUS", Value: func(c mailerlite.Campaign) string { return c.Status }},
+		{Name: "language_id", Header: "LANGUAGE", Value: func(c mailerlite.Campaign) string { return c.LanguageID }},
+		{Name: "stats.sent", Header: "SENT", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.Sent) }},
+		{Name: "stats.opens_count", Header: "OPENS", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.OpensCount) }},
+		{Name: "stats.unique_opens_count", Header: "UNIQUE OPENS", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.UniqueOpensCount) }},
+		{Name: "stats.open_rate", Header: "OPEN RATE", Value: func(c mailerlite.Campaign) string { return c.Stats.OpenRate.String }},
+		{Name: "stats.clicks_count", Header: "CLICKS", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.ClicksCount) }},
+		{Name: "stats.unique_clicks_count", Header: "UNIQUE CLICKS", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.UniqueClicksCount) }},
+		{Name: "stats.click_rate", Header: "CLICK RATE", Value: func(c mailerlite.Campaign) string { return c.Stats.ClickRate.String }},
+		{Name: "stats.click_to_open_rate", Header: "CLICK TO OPEN", Value: func(c mailerlite.Campaign) string { return c.Stats.ClickToOpenRate.String }},
+		{Name: "stats.unsubscribes_count", Header: "UNSUBSCRIBES", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.UnsubscribesCount) }},
+		{Name: "stats.unsubscribe_rate", Header: "UNSUBSCRIBE RATE", Value: func(c mailerlite.Campaign) string { return c.Stats.UnsubscribeRate.String }},
+		{Name: "stats.spam_count", Header: "SPAM", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.SpamCount) }},
+		{Name: "stats.hard_bounces_count", Header: "HARD BOUNCES", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.HardBouncesCount) }},
+		{Name: "stats.soft_bounces_count", Header: "SOFT BOUNCES", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.SoftBouncesCount) }},
+		{Name: "stats.forwards_count", Header: "FORWARDS", Value: func(c mailerlite.Campaign) string { return itoa(c.Stats.ForwardsCount) }},
+		{Name: "created_at", Header: "CREATED AT", Value: func(c mailerlite.Campaign) string { return c.CreatedAt }},
+		{Name: "scheduled_for", Header: "SCHEDULED FOR", Value: func(c mailerlite.Campaign) string { return c.ScheduledFor }},
+		{Name: "finished_at", Header: "FINISHED AT", Value: func(c mailerlite.Campaign) string { return c.FinishedAt }},
+	},
+)
+
+// CampaignSubscriber holds the columns for the recipients of a campaign.
+var CampaignSubscriber = output.NewColumnSet(
+	[]string{"id", "email", "opens_count", "clicks_count"},
+	[]output.Column[mailerlite.CampaignSubscriber]{
+		{Name: "id", Header: "ID", Value: func(s mailerlite.CampaignSubscriber) string { return s.ID }},
+		{Name: "email", Header: "EMAIL", Value: func(s mailerlite.CampaignSubscriber) string { return s.Subscriber.Email }},
+		{Name: "status", Header: "STATUS", Value: func(s mailerlite.CampaignSubscriber) string { return s.Subscriber.Status }},
+		{Name: "opens_count", Header: "OPENS", Value: func(s mailerlite.CampaignSubscriber) string { return itoa(s.OpensCount) }},
+		{Name: "clicks_count", Header: "CLICKS", Value: func(s mailerlite.CampaignSubscriber) string { return itoa(s.ClicksCount) }},
+	},
+)
+
+// CampaignLanguage holds the columns for campaign languages.
+var CampaignLanguage = output.NewColumnSet(
+	[]string{"id", "name", "shortcode"},
+	[]output.Column[mailerlite.CampaignLanguage]{
+		{Name: "id", Header: "ID", Value: func(l mailerlite.CampaignLanguage) string { return l.Id }},
+		{Name: "name", Header: "NAME", Value: func(l mailerlite.CampaignLanguage) string { return l.Name }},
+		{Name: "shortcode", Header: "SHORTCODE", Value: func(l mailerlite.CampaignLanguage) string { return l.Shortcode }},
+		{Name: "iso639", Header: "ISO 639", Value: func(l mailerlite.CampaignLanguage) string { return l.Iso639 }},
+		{Name: "direction", Header: "DIRECTION", Value: func(l mailerlite.CampaignLanguage) string { return l.Direction }},
+	},
+)
+
+// Group holds the columns for groups.
+var Group = output.NewColumnSet(
+	[]string{"id", "name", "active_count", "sent_count", "opens_count", "click_rate", "created_at"},
+	[]output.Column[mailerlite.Group]{
+		{Name: "id", Header: "ID", Value: func(g mailerlite.Group) string { return g.ID }},
+		{Name: "name", Header: "NAME", Value: func(g mailerlite.Group) string { return g.Name }},
+		{Name: "active_count", Header: "ACTIVE", Value: func(g mailerlite.Group) string { return itoa(g.ActiveCount) }},
+		{Name: "sent_count", Header: "SENT", Value: func(g mailerlite.Group) string { return itoa(g.SentCount) }},
+		{Name: "opens_count", Header: "OPENS", Value: func(g mailerlite.Group) string { return itoa(g.OpensCount) }},
+		{Name: "open_rate", Header: "OPEN RATE", Value: func(g mailerlite.Group) string { return g.OpenRate.String }},
+		{Name: "clicks_count", Header: "CLICKS", Value: func(g mailerlite.Group) string { return itoa(g.ClicksCount) }},
+		{Name: "click_rate", Header: "CLICK RATE", Value: func(g mailerlite.Group) string { return g.ClickRate.String }},
+		{Name: "unsubscribed_count", Header: "UNSUBSCRIBED", Value: func(g mailerlite.Group) string { return itoa(g.UnsubscribedCount) }},
+		{Name: "unconfirmed_count", Header: "UNCONFIRMED", Value: func(g mailerlite.Group) string { return itoa(g.UnconfirmedCount) }},
+		{Name: "bounced_count", Header: "BOUNCED", Value: func(g mailerlite.Group) string { return itoa(g.BouncedCount) }},
+		{Name: "junk_count", Header: "JUNK", Value: func(g mailerlite.Group) string { return itoa(g.JunkCount) }},
+		{Name: "created_at", Header: "CREATED AT", Value: func(g mailerlite.Group) string { return g.CreatedAt }},
+	},
+)
+
+// Segment holds the columns for segments.
+var Segment = output.NewColumnSet(
+	[]string{"id", "name", "total", "open_rate", "click_rate", "created_at"},
+	[]output.Column[mailerlite.Segment]{
+		{Name: "id", Header: "ID", Value: func(s mailerlite.Segment) string { return s.ID }},
+		{Name: "name", Header: "NAME", Value: func(s mailerlite.Segment) string { return s.Name }},
+		{Name: "total", Header: "TOTAL", Value: func(s mailerlite.Segment) string { return itoa(s.Total) }},
+		{Name: "open_rate", Header: "OPEN RATE", Value: func(s mailerlite.Segment) string { return s.OpenRate.String }},
+		{Name: "click_rate", Header: "CLICK RATE", Value: func(s mailerlite.Segment) string { return s.ClickRate.String }},
+		{Name: "created_at", Header: "CREATED AT", Value: func(s mailerlite.Segment) string { return s.CreatedAt }},
+	},
+)
+
+// Form holds the columns for forms.
+var Form = output.NewColumnSet(
+	[]string{"id", "name", "type", "active", "conversions_count", "opens_count"},
+	[]output.Column[mailerlite.Form]{
+		{Name: "id", Header: "ID", Value: func(f mailerlite.Form) string { return f.Id }},
+		{Name: "name", Header: "NAME", Value: func(f mailerlite.Form) string { return f.Name }},
+		{Name: "type", Header: "TYPE", Value: func(f mailerlite.Form) string { return f.Type }},
+		{Name: "slug", Header: "SLUG", Value: func(f mailerlite.Form) string { return f.Slug }},
+		{Name: "active", Header: "ACTIVE", Value: func(f mailerlite.Form) string { return yesNo(f.Active) }},
+		{Name: "conversions_count", Header: "CONVERSIONS", Value: func(f mailerlite.Form) string { return itoa(f.ConversionsCount) }},
+		{Name: "conversions_rate", Header: "CONVERSION RATE", Value: func(f mailerlite.Form) string { return f.ConversionsRate.String }},
+		{Name: "opens_count", Header: "OPENS", Value: func(f mailerlite.Form) string { return itoa(f.OpensCount) }},
+		{Name: "created_at", Header: "CREATED AT", Value: func(f mailerlite.Form) string { return f.CreatedAt }},
+	},
+)
+
+// Automation holds the columns for automations.
+var Automation = output.NewColumnSet(
+	[]string{"id", "name", "enabled", "emails_count", "stats.completed_subscribers_count", "stats.subscribers_in_queue_count"},
+	[]output.Column[mailerlite.Automation]{
+		{Name: "id", Header: "ID", Value: func(a mailerlite.Automation) string { return a.ID }},
+		{Name: "name", Header: "NAME", Value: func(a mailerlite.Automation) string { return a.Name }},
+		{Name: "enabled", Header: "ENABLED", Value: func(a mailerlite.Automation) string { return yesNo(a.Enabled) }},
+		{Name: "complete", Header: "COMPLETE", Value: func(a mailerlite.Automation) string { return yesNo(a.Complete) }},
+		{Name: "broken", Header: "BROKEN", Value: func(a mailerlite.Automation) string { return yesNo(a.Broken) }},
+		{Name: "emails_count", Header: "EMAILS", Value: func(a mailerlite.Automation) string { return itoa(a.EmailsCount) }},
+		{Name: "qualified_subscribers_count", Header: "QUALIFIED", Value: func(a mailerlite.Automation) string { return itoa(a.QualifiedSubscribersCount) }},
+		{Name: "stats.completed_subscribers_count", Header: "COMPLETED", Value: func(a mailerlite.Automation) string { return itoa(a.Stats.CompletedSubscribersCount) }},
+		{Name: "stats.subscribers_in_queue_count", Header: "IN QUEUE", Value: func(a mailerlite.Automation) string { return itoa(a.Stats.SubscribersInQueueCount) }},
+		{Name: "stats.sent", Header: "SENT", Value: func(a mailerlite.Automation) string { return itoa(a.Stats.Sent) }},
+		{Name: "stats.opens_count", Header: "OPENS", Value: func(a mailerlite.Automation) string { return itoa(a.Stats.OpensCount) }},
+		{Name: "stats.clicks_count", Header: "CLICKS", Value: func(a mailerlite.Automation) string { return itoa(a.Stats.ClicksCount) }},
+		{Name: "stats.unsubscribes_count", Header: "UNSUBSCRIBES", Value: func(a mailerlite.Automation) string { return itoa(a.Stats.UnsubscribesCount) }},
+		{Name: "created_at", Header: "CREATED AT", Value: func(a mailerlite.Automation) string { return a.CreatedAt }},
+	},
+)
+
+// AutomationSubscriber holds the columns for the subscribers in an automation.
+var AutomationSubscriber = output.NewColumnSet(
+	[]string{"id", "email", "status", "date"},
+	[]output.Column[mailerlite.AutomationSubscriber]{
+		{Name: "id", Header: "ID", Value: func(s mailerlite.AutomationSubscriber) string { return s.ID }},
+		{Name: "email", Header: "EMAIL", Value: func(s mailerlite.AutomationSubscriber) string { return s.Subscriber.Email }},
+		{Name: "status", Header: "STATUS", Value: func(s mailerlite.AutomationSubscriber) string { return s.Status }},
+		{Name: "date", Header: "DATE", Value: func(s mailerlite.AutomationSubscriber) string { return s.Date }},
+		{Name: "reason_description", Header: "REASON", Value: func(s mailerlite.AutomationSubscriber) string { return s.ReasonDescription }},
+	},
+)
+
+// Webhook holds the columns for webhooks.
+var Webhook = output.NewColumnSet(
+	[]string{"id", "name", "url", "enabled", "created_at"},
+	[]output.Column[mailerlite.Webhook]{
+		{Name: "id", Header: "ID", Value: func(w mailerlite.Webhook) string { return w.Id }},
+		{Name: "name", Header: "NAME", Value: func(w mailerlite.Webhook) string { return w.Name }},
+		{Name: "url", Header: "URL", Value: func(w mailerlite.Webhook) string { return w.Url }},
+		{Name: "enabled", Header: "ENABLED", Value: func(w mailerlite.Webhook) string { return yesNo(w.Enabled) }},
+		{Name: "events", Header: "EVENTS", Value: func(w mailerlite.Webhook) string { return strings.Join(w.Events, ", ") }},
+		{Name: "created_at", Header: "CREATED AT", Value: func(w mailerlite.Webhook) string { return w.CreatedAt }},
+		{Name: "updated_at", Header: "UPDATED AT", Value: func(w mailerlite.Webhook) string { return w.UpdatedAt }},
+	},
+)
+
+// Field holds the columns for subscriber fields.
+var Field = output.NewColumnSet(
+	[]string{"id", "name", "key", "type"},
+	[]output.Column[mailerlite.Field]{
+		{Name: "id", Header: "ID", Value: func(f mailerlite.Field) string { return f.Id }},
+		{Name: "name", Header: "NAME", Value: func(f mailerlite.Field) string { return f.Name }},
+		{Name: "key", Header: "KEY", Value: func(f mailerlite.Field) string { return f.Key }},
+		{Name: "type", Header: "TYPE", Value: func(f mailerlite.Field) string { return f.Type }},
+	},
+)
+
+// Timezone holds the columns for timezones.
+var Timezone = output.NewColumnSet(
+	[]string{"id", "name", "offset"},
+	[]output.Column[mailerlite.Timezone]{
+		{Name: "id", Header: "ID", Value: func(tz mailerlite.Timezone) string { return tz.Id }},
+		{Name: "name", Header: "NAME", Value: func(tz mailerlite.Timezone) string { return tz.Name }},
+		{Name: "name_for_humans", Header: "DISPLAY NAME", Value: func(tz mailerlite.Timezone) string { return tz.NameForHumans }},
+		{Name: "offset_name", Header: "OFFSET NAME", Value: func(tz mailerlite.Timezone) string { return tz.OffsetName }},
+		{Name: "offset", Header: "OFFSET", Value: func(tz mailerlite.Timezone) string { return itoa(tz.Offset) }},
+	},
+)
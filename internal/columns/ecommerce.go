@@ -0,0 +1,105 @@
+package columns
+
+import (
+	"strconv"
+
+	"github.com/mailerlite/mailerlite-cli/internal/ecommerce"
+	"github.com/mailerlite/mailerlite-cli/internal/output"
+)
+
+func price(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+// Shop holds the columns for e-commerce shops.
+var Shop = output.NewColumnSet(
+	[]string{"id", "name", "url", "created_at"},
+	[]output.Column[ecommerce.Shop]{
+		{Name: "id", Header: "ID", Value: func(s ecommerce.Shop) string { return s.ID }},
+		{Name: "name", Header: "NAME", Value: func(s ecommerce.Shop) string { return s.Name }},
+		{Name: "url", Header: "URL", Value: func(s ecommerce.Shop) string { return s.URL }},
+		{Name: "created_at", Header: "CREATED", Value: func(s ecommerce.Shop) string { return s.CreatedAt }},
+		{Name: "updated_at", Header: "UPDATED", Value: func(s ecommerce.Shop) string { return s.UpdatedAt }},
+	},
+)
+
+// Product holds the columns for e-commerce products.
+var Product = output.NewColumnSet(
+	[]string{"id", "name", "price", "quantity", "created_at"},
+	[]output.Column[ecommerce.Product]{
+		{Name: "id", Header: "ID", Value: func(p ecommerce.Product) string { return p.ID }},
+		{Name: "name", Header: "NAME", Value: func(p ecommerce.Product) string { return p.Name }},
+		{Name: "price", Header: "PRICE", Value: func(p ecommerce.Product) string { return price(p.Price) }},
+		{Name: "quantity", Header: "QUANTITY", Value: func(p ecommerce.Product) string { return itoa(p.Quantity) }},
+		{Name: "url", Header: "URL", Value: func(p ecommerce.Product) string { return p.URL }},
+		{Name: "image_url", Header: "IMAGE URL", Value: func(p ecommerce.Product) string { return p.ImageURL }},
+		{Name: "description", Header: "DESCRIPTION", Value: func(p ecommerce.Product) string { return p.Description }},
+		{Name: "created_at", Header: "CREATED", Value: func(p ecommerce.Product) string { return p.CreatedAt }},
+		{Name: "updated_at", Header: "UPDATED", Value: func(p ecommerce.Product) string { return p.UpdatedAt }},
+	},
+)
+
+// Category holds the columns for e-commerce categories.
+var Category = output.NewColumnSet(
+	[]string{"id", "name", "created_at"},
+	[]output.Column[ecommerce.Category]{
+		{Name: "id", Header: "ID", Value: func(c ecommerce.Category) string { return c.ID }},
+		{Name: "name", Header: "NAME", Value: func(c ecommerce.Category) string { return c.Name }},
+		{Name: "created_at", Header: "CREATED", Value: func(c ecommerce.Category) string { return c.CreatedAt }},
+		{Name: "updated_at", Header: "UPDATED", Value: func(c ecommerce.Category) string { return c.UpdatedAt }},
+	},
+)
+
+// Customer holds the columns for e-commerce customers.
+var Customer = output.NewColumnSet(
+	[]string{"id", "email", "first_name", "last_name", "created_at"},
+	[]output.Column[ecommerce.Customer]{
+		{Name: "id", Header: "ID", Value: func(c ecommerce.Customer) string { return c.ID }},
+		{Name: "email", Header: "EMAIL", Value: func(c ecommerce.Customer) string { return c.Email }},
+		{Name: "first_name", Header: "FIRST NAME", Value: func(c ecommerce.Customer) string { return c.FirstName }},
+		{Name: "last_name", Header: "LAST NAME", Value: func(c ecommerce.Customer) string { return c.LastName }},
+		{Name: "created_at", Header: "CREATED", Value: func(c ecommerce.Customer) string { return c.CreatedAt }},
+		{Name: "updated_at", Header: "UPDATED", Value: func(c ecommerce.Customer) string { return c.UpdatedAt }},
+	},
+)
+
+// Order holds the columns for e-commerce orders.
+var Order = output.NewColumnSet(
+	[]string{"id", "customer_id", "status", "total", "currency", "created_at"},
+	[]output.Column[ecommerce.Order]{
+		{Name: "id", Header: "ID", Value: func(o ecommerce.Order) string { return o.ID }},
+		{Name: "customer_id", Header: "CUSTOMER", Value: func(o ecommerce.Order) string { return o.CustomerID }},
+		{Name: "status", Header: "STATUS", Value: func(o ecommerce.Order) string { return o.Status }},
+		{Name: "total", Header: "TOTAL", Value: func(o ecommerce.Order) string { return price(o.Total) }},
+		{Name: "currency", Header: "CURRENCY", Value: func(o ecommerce.Order) string { return o.Currency }},
+		{Name: "items", Header: "ITEMS", Value: func(o ecommerce.Order) string { return itoa(len(o.Items)) }},
+		{Name: "created_at", Header: "CREATED", Value: func(o ecommerce.Order) string { return o.CreatedAt }},
+		{Name: "updated_at", Header: "UPDATED", Value: func(o ecommerce.Order) string { return o.UpdatedAt }},
+	},
+)
+
+// Cart holds the columns for e-commerce carts.
+var Cart = output.NewColumnSet(
+	[]string{"id", "customer_id", "currency", "total", "created_at"},
+	[]output.Column[ecommerce.Cart]{
+		{Name: "id", Header: "ID", Value: func(c ecommerce.Cart) string { return c.ID }},
+		{Name: "customer_id", Header: "CUSTOMER", Value: func(c ecommerce.Cart) string { return c.CustomerID }},
+		{Name: "currency", Header: "CURRENCY", Value: func(c ecommerce.Cart) string { return c.Currency }},
+		{Name: "total", Header: "TOTAL", Value: func(c ecommerce.Cart) string { return price(c.Total) }},
+		{Name: "created_at", Header: "CREATED", Value: func(c ecommerce.Cart) string { return c.CreatedAt }},
+		{Name: "updated_at", Header: "UPDATED", Value: func(c ecommerce.Cart) string { return c.UpdatedAt }},
+	},
+)
+
+// CartItem holds the columns for the items in an e-commerce cart.
+var CartItem = output.NewColumnSet(
+	[]string{"id", "product_id", "quantity", "price", "created_at"},
+	[]output.Column[ecommerce.CartItem]{
+		{Name: "id", Header: "ID", Value: func(i ecommerce.CartItem) string { return i.ID }},
+		{Name: "product_id", Header: "PRODUCT", Value: func(i ecommerce.CartItem) string { return i.ProductID }},
+		{Name: "quantity", Header: "QUANTITY", Value: func(i ecommerce.CartItem) string { return itoa(i.Quantity) }},
+		{Name: "price", Header: "PRICE", Value: func(i ecommerce.CartItem) string { return price(i.Price) }},
+		{Name: "created_at", Header: "CREATED", Value: func(i ecommerce.CartItem) string { return i.CreatedAt }},
+		{Name: "updated_at", Header: "UPDATED", Value: func(i ecommerce.CartItem) string { return i.UpdatedAt }},
+	},
+)
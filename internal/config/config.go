@@ -24,6 +24,7 @@ type Profile struct {
 	OAuthRefreshToken string `yaml:"oauth_refresh_token,omitempty"`
 	OAuthExpiresAt    string `yaml:"oauth_expires_at,omitempty"`
 	AccountID         string `yaml:"account_id,omitempty"`
+	BaseURL           string `yaml:"base_url,omitempty"`
 }
 
 type Config struct {
@@ -151,6 +152,7 @@ func GetToken(profileOverride string) (string, error) {
 				refreshed, refreshErr := refreshOAuthToken(prof.OAuthRefreshToken)
 				if refreshErr == nil {
 					refreshed.AccountID = prof.AccountID
+					refreshed.BaseURL = prof.BaseURL
 					cfg.Profiles[profName] = refreshed
 					_ = Save(cfg)
 					return refreshed.OAuthToken, nil
@@ -169,27 +171,38 @@ func GetToken(profileOverride string) (string, error) {
 
 // GetAccountID returns the stored account ID for the active (or overridden) profile.
 func GetAccountID(profileOverride string) string {
+	prof, _ := lookupProfile(profileOverride)
+	return prof.AccountID
+}
+
+// GetBaseURL returns the API base URL for the active (or overridden)
+// profile, or "" for the default. MAILERLITE_API_BASE_URL takes precedence
+// over the profile's base_url.
+func GetBaseURL(profileOverride string) string {
+	if base := os.Getenv("MAILERLITE_API_BASE_URL"); base != "" {
+		return strings.TrimSuffix(base, "/")
+	}
+	prof, _ := lookupProfile(profileOverride)
+	return strings.TrimSuffix(prof.BaseURL, "/")
+}
+
+// lookupProfile returns the overridden profile, or the active one. It
+// reports false if the config cannot be loaded or the profile is missing.
+func lookupProfile(profileOverride string) (Profile, bool) {
 	cfg, err := Load()
 	if err != nil {
-		return ""
+		return Profile{}, false
 	}
 
-	var prof Profile
 	if profileOverride != "" {
 		p, ok := cfg.Profiles[profileOverride]
-		if !ok {
-			return ""
-		}
-		prof = p
-	} else {
-		_, p, err := ActiveProfile(cfg)
-		if err != nil {
-			return ""
-		}
-		prof = p
+		return p, ok
 	}
-
-	return prof.AccountID
+	_, p, err := ActiveProfile(cfg)
+	if err != nil {
+		return Profile{}, false
+	}
+	return p, true
 }
 
 // refreshOAuthToken exchanges a refresh token for a new access token.
@@ -0,0 +1,126 @@
+// Package content prepares campaign email content: it reads HTML and plain
+// text from files, fills in template variables, renders HTML as plain text
+// for the terminal, and lints content before it is sent.
+package content
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadFile reads content from path, or from stdin if path is "-".
+func ReadFile(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+// LoadVars reads template variables from a YAML or JSON file.
+func LoadVars(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	vars := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &vars); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// bareVar matches a Handlebars-style variable reference such as {{ name }}
+// or {{company.address}}, which Go templates write as {{.name}}.
+var bareVar = regexp.MustCompile(`{{(-?\s*)([A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*)(\s*-?)}}`)
+
+// keywords are the template actions and functions that must not be turned
+// into variable references.
+var keywords = map[string]bool{
+	"if": true, "else": true, "end": true, "range": true, "with": true, "define": true,
+	"template": true, "block": true, "break": true, "continue": true, "nil": true,
+	"and": true, "or": true, "not": true, "len": true, "index": true, "slice": true,
+	"print": true, "printf": true, "println": true, "html": true, "js": true, "urlquery": true,
+	"eq": true, "ne": true, "lt": true, "le": true, "gt": true, "ge": true, "call": true,
+}
+
+// Render fills in the variables of a Go template. Handlebars-style
+// references like {{name}} are accepted as well as {{.name}}. Values are
+// inserted as-is, and a reference to a missing variable is an error.
+// MailerLite merge tags such as {$name} are left alone.
+func Render(name, text string, vars map[string]interface{}) (string, error) {
+	text = bareVar.ReplaceAllStringFunc(text, func(m string) string {
+		parts := bareVar.FindStringSubmatch(m)
+		if keywords[parts[2]] {
+			return m
+		}
+		return "{{" + parts[1] + "." + parts[2] + parts[3] + "}}"
+	})
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+var (
+	hiddenElement = regexp.MustCompile(`(?is)<(head|style|script|title)\b.*?</(head|style|script|title)\s*>|<!--.*?-->`)
+	link          = regexp.MustCompile(`(?is)<a\b[^>]*?\bhref\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a\s*>`)
+	lineBreak     = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|tr|table|ul|ol|blockquote)\s*>`)
+	listItem      = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	tag           = regexp.MustCompile(`(?s)<[^>]*>`)
+	spaces        = regexp.MustCompile(`[ \t\r\f\v\p{Zs}]+`)
+	blankLines    = regexp.MustCompile(`\n{3,}`)
+)
+
+// Text renders HTML email content as plain text for reading in a terminal:
+// block elements become line breaks, links are followed by their URL, and
+// everything else but the text is dropped.
+func Text(doc string) string {
+	s := hiddenElement.ReplaceAllString(doc, "")
+	s = strings.ReplaceAll(s, "\n", " ")
+	s = link.ReplaceAllStringFunc(s, func(m string) string {
+		parts := link.FindStringSubmatch(m)
+		text := strings.TrimSpace(tag.ReplaceAllString(parts[2], ""))
+		href := strings.TrimSpace(parts[1])
+		if href == "" || text == href || strings.HasPrefix(href, "#") {
+			return parts[2]
+		}
+		return parts[2] + " (" + href + ")"
+	})
+	s = lineBreak.ReplaceAllString(s, "\n")
+	s = listItem.ReplaceAllString(s, "\n  * ")
+	s = tag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		indent := strings.HasPrefix(line, "  * ")
+		line = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+		if indent {
+			line = "  " + line
+		}
+		lines[i] = line
+	}
+	s = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(s, "\n\n")) + "\n"
+}
@@ -0,0 +1,161 @@
+package content
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Severities of lint issues. Errors make a campaign fail lint; warnings are
+// reported only.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// MaxHTMLSize is the HTML size above which Gmail clips an email.
+const MaxHTMLSize = 102 * 1024
+
+// systemTags are the merge tags MailerLite fills in besides field keys.
+var systemTags = []string{"email", "unsubscribe", "preferences", "url"}
+
+// Issue is a problem found in campaign content.
+type Issue struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+// Email is the content to lint.
+type Email struct {
+	Subject   string
+	HTML      string
+	PlainText string
+}
+
+var (
+	anchor    = regexp.MustCompile(`(?is)<a\b([^>]*)>`)
+	image     = regexp.MustCompile(`(?is)<img\b([^>]*)>`)
+	hrefAttr  = regexp.MustCompile(`(?is)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	altAttr   = regexp.MustCompile(`(?is)\balt\s*=`)
+	srcAttr   = regexp.MustCompile(`(?is)\bsrc\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	mergeTag  = regexp.MustCompile(`{\$([A-Za-z0-9_]+)}`)
+	openTag   = regexp.MustCompile(`{\$[^}\s]*(\s|$)`)
+	leftover  = regexp.MustCompile(`{{[^}]*}}|{%[^%]*%}`)
+	unsubLink = regexp.MustCompile(`(?i){\$unsubscribe}|{\$preferences}|href\s*=\s*["'][^"']*unsubscribe`)
+	mergeOnly = regexp.MustCompile(`^{\$[A-Za-z0-9_]+}$`)
+)
+
+// Lint checks campaign content for problems that commonly hurt delivery or
+// rendering. fieldKeys are the custom field keys of the account, which are
+// valid merge tags.
+func Lint(e Email, fieldKeys []string) []Issue {
+	var issues []Issue
+	add := func(severity, rule, format string, args ...interface{}) {
+		issues = append(issues, Issue{Severity: severity, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(e.HTML) == "" {
+		add(SeverityError, "content", "the email has no HTML content")
+		return issues
+	}
+
+	if !unsubLink.MatchString(e.HTML) {
+		add(SeverityError, "unsubscribe", "no unsubscribe link; add {$unsubscribe}")
+	}
+
+	for _, m := range anchor.FindAllStringSubmatch(e.HTML, -1) {
+		href, ok := attr(hrefAttr, m[1])
+		if !ok {
+			continue
+		}
+		if problem := checkLink(href); problem != "" {
+			add(SeverityError, "links", "%s: %q", problem, href)
+		} else if strings.HasPrefix(strings.ToLower(href), "http://") {
+			add(SeverityWarning, "https", "link is not HTTPS: %q", href)
+		}
+	}
+
+	for _, m := range image.FindAllStringSubmatch(e.HTML, -1) {
+		if !altAttr.MatchString(m[1]) {
+			src, _ := attr(srcAttr, m[1])
+			add(SeverityWarning, "alt-text", "image has no alt text: %q", src)
+		}
+	}
+
+	if size := len(e.HTML); size > MaxHTMLSize {
+		add(SeverityWarning, "size", "HTML is %d KB; Gmail clips emails over %d KB", size/1024, MaxHTMLSize/1024)
+	}
+
+	for _, text := range []string{e.Subject, e.HTML, e.PlainText} {
+		for _, m := range leftover.FindAllString(text, -1) {
+			add(SeverityError, "merge-tags", "unresolved template tag %s", m)
+		}
+		for _, m := range openTag.FindAllString(text, -1) {
+			add(SeverityError, "merge-tags", "merge tag is not closed: %s", strings.TrimSpace(m))
+		}
+		for _, m := range mergeTag.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(systemTags, m[1]) && !slices.Contains(fieldKeys, m[1]) {
+				add(SeverityWarning, "merge-tags", "merge tag %s is not a field of the account", m[0])
+			}
+		}
+	}
+
+	if strings.TrimSpace(e.PlainText) == "" {
+		add(SeverityWarning, "plain-text", "no plain text version")
+	}
+	return dedupe(issues)
+}
+
+// checkLink returns what is wrong with a link target, or "".
+func checkLink(href string) string {
+	href = strings.TrimSpace(href)
+	switch {
+	case href == "" || href == "#":
+		return "empty link"
+	case mergeOnly.MatchString(href), strings.HasPrefix(href, "#"):
+		return ""
+	}
+	// Merge tags are filled in when sending, e.g. in a query string.
+	u, err := url.Parse(mergeTag.ReplaceAllString(href, "tag"))
+	if err != nil {
+		return "malformed link"
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		if u.Host == "" || strings.ContainsAny(u.Host, " {}") {
+			return "link has no valid host"
+		}
+	case "mailto", "tel", "sms":
+		if u.Opaque == "" && u.Path == "" {
+			return "empty " + u.Scheme + " link"
+		}
+	case "":
+		return "relative link"
+	default:
+		return "unsupported link scheme " + u.Scheme
+	}
+	return ""
+}
+
+func attr(re *regexp.Regexp, attrs string) (string, bool) {
+	m := re.FindStringSubmatch(attrs)
+	if m == nil {
+		return "", false
+	}
+	return m[1] + m[2] + m[3], true
+}
+
+func dedupe(issues []Issue) []Issue {
+	seen := map[Issue]bool{}
+	out := issues[:0]
+	for _, i := range issues {
+		if !seen[i] {
+			seen[i] = true
+			out = append(out, i)
+		}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package content
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	const footer = `<a href="{$unsubscribe}">Unsubscribe</a>`
+	tests := []struct {
+		name  string
+		email Email
+		want  []string // "severity rule"
+	}{
+		{
+			name:  "clean",
+			email: Email{Subject: "Hi {$name}", HTML: `<p>Hi</p><a href="https://example.com/?id={$email}">Shop</a><img src="a.png" alt="">` + footer, PlainText: "Hi"},
+		},
+		{
+			name:  "no HTML",
+			email: Email{Subject: "Hi", PlainText: "Hi"},
+			want:  []string{"error content"},
+		},
+		{
+			name:  "no unsubscribe link",
+			email: Email{HTML: "<p>Hi</p>", PlainText: "Hi"},
+			want:  []string{"error unsubscribe"},
+		},
+		{
+			name:  "unsubscribe URL counts",
+			email: Email{HTML: `<a href="https://example.com/unsubscribe">Leave</a>`, PlainText: "Hi"},
+		},
+		{
+			name:  "broken links",
+			email: Email{HTML: `<a href="#">x</a><a href="/about">x</a><a href="ftp://example.com">x</a><a href="mailto:">x</a><a href="#top">x</a><a href="{$url}">x</a>` + footer, PlainText: "Hi"},
+			want:  []string{"error links", "error links", "error links", "error links"},
+		},
+		{
+			name:  "insecure link and image without alt",
+			email: Email{HTML: `<a href="http://example.com">x</a><img src="a.png">` + footer, PlainText: "Hi"},
+			want:  []string{"warning https", "warning alt-text"},
+		},
+		{
+			name:  "merge tags",
+			email: Email{Subject: "Hi {$nickname}", HTML: `<p>{{ name }} {$name</p>` + footer, PlainText: "Hi {$plan}"},
+			want:  []string{"warning merge-tags", "error merge-tags", "error merge-tags"},
+		},
+		{
+			name:  "large and without plain text",
+			email: Email{HTML: strings.Repeat("x", MaxHTMLSize+1) + footer},
+			want:  []string{"warning size", "warning plain-text"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range Lint(tt.email, []string{"name", "plan"}) {
+				got = append(got, issue.Severity+" "+issue.Rule)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintReportsEachIssueOnce(t *testing.T) {
+	issues := Lint(Email{HTML: `<img src="a.png"><img src="a.png">` + `{$unsubscribe}`, PlainText: "Hi"}, nil)
+	if len(issues) != 1 {
+		t.Errorf("got %d issues, want 1: %+v", len(issues), issues)
+	}
+}
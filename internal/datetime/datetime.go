@@ -0,0 +1,182 @@
+// Package datetime parses the human-friendly dates, times and durations
+// accepted by scheduling flags, such as "2026-11-02 09:30", "tomorrow 9am",
+// "next monday 14:00", "in 2 hours" and "48h".
+package datetime
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embed the timezone database so that --tz works where the system has
+	// none, e.g. on Windows.
+	_ "time/tzdata"
+)
+
+// Formats describes the accepted forms, for help texts and errors.
+const Formats = `"2026-11-02 09:30", "tomorrow 9am", "friday 14:00", "next monday 9:30am", "in 2h", "in 3 days"`
+
+var (
+	relative  = regexp.MustCompile(`^(?:in\s+|\+)(\d+)\s*([a-z]+)$`)
+	duration  = regexp.MustCompile(`^(\d+)\s*([a-z]+)$`)
+	clock     = regexp.MustCompile(`^(\d{1,2})(?:[:.](\d{2}))?\s*(am|pm)?$`)
+	isoLayout = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05"}
+)
+
+var units = map[string]time.Duration{
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Parse parses value as a point in time in the location of now. Relative
+// forms count from now; a day without a time of day is an error, and a time
+// of day without a day means today.
+func Parse(value string, now time.Time) (time.Time, error) {
+	s := strings.Join(strings.Fields(strings.ToLower(value)), " ")
+	loc := now.Location()
+
+	for _, layout := range isoLayout {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(s), loc); err == nil {
+			return t, nil
+		}
+	}
+	if m := relative.FindStringSubmatch(s); m != nil {
+		d, err := ParseDuration(m[1] + m[2])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q: %w", value, err)
+		}
+		return now.Add(d).Truncate(time.Minute), nil
+	}
+
+	day, clockPart, err := splitDay(s, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: %w; use a form like %s", value, err, Formats)
+	}
+	clockPart = strings.TrimSpace(strings.TrimPrefix(clockPart, "at "))
+	if clockPart == "" {
+		return time.Time{}, fmt.Errorf("invalid time %q: add a time of day, e.g. %q", value, s+" 9:00")
+	}
+	hour, minute, err := parseClock(clockPart)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: %w; use a form like %s", value, err, Formats)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc), nil
+}
+
+// splitDay parses the leading day of s, defaulting to today, and returns the
+// rest of s.
+func splitDay(s string, now time.Time) (time.Time, string, error) {
+	word, rest, _ := strings.Cut(s, " ")
+	if t, err := time.ParseInLocation(time.DateOnly, word, now.Location()); err == nil {
+		return t, rest, nil
+	}
+	switch word {
+	case "today":
+		return now, rest, nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1), rest, nil
+	case "next":
+		word, rest, _ = strings.Cut(rest, " ")
+		if wd, ok := weekdays[word]; ok {
+			return nextWeekday(now, wd), rest, nil
+		}
+		return time.Time{}, "", fmt.Errorf("%q is not a day of the week", word)
+	}
+	if wd, ok := weekdays[word]; ok {
+		return nextWeekday(now, wd), rest, nil
+	}
+	if _, _, err := parseClock(s); err == nil {
+		return now, s, nil
+	}
+	return time.Time{}, "", fmt.Errorf("unrecognised day %q", word)
+}
+
+// nextWeekday returns the next day after now that falls on wd.
+func nextWeekday(now time.Time, wd time.Weekday) time.Time {
+	days := (int(wd) - int(now.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return now.AddDate(0, 0, days)
+}
+
+// parseClock parses a time of day such as "9am", "9:30pm", "14:00" or "noon".
+func parseClock(s string) (hour, minute int, err error) {
+	switch s {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+	m := clock.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, fmt.Errorf("unrecognised time of day %q", s)
+	}
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	switch m[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("invalid hour in %q", s)
+		}
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+	case "":
+		if m[2] == "" {
+			return 0, 0, fmt.Errorf("ambiguous time of day %q: add minutes or am/pm", s)
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return hour, minute, nil
+}
+
+// ParseDuration parses a duration such as "48h", "2d", "90 minutes" or
+// "1 week".
+func ParseDuration(value string) (time.Duration, error) {
+	m := duration.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration %q: use a form like 48h, 2d or 90m", value)
+	}
+	n, _ := strconv.Atoi(m[1])
+	unit, ok := units[m[2]]
+	if !ok {
+		return 0, fmt.Errorf("invalid duration %q: unknown unit %q", value, m[2])
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// LocalZone returns the IANA name of the local timezone, e.g.
+// "Europe/Vilnius", taken from TZ or the /etc/localtime link, or "" if it
+// is not known.
+func LocalZone() string {
+	name := time.Local.String()
+	if name == "Local" {
+		link, err := os.Readlink("/etc/localtime")
+		if err != nil {
+			return ""
+		}
+		_, name, _ = strings.Cut(link, "zoneinfo/")
+	}
+	if _, err := time.LoadLocation(name); err != nil || name == "" || name == "Local" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,126 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A Monday in the week the clocks go forward, on Sunday 29 March at
+	// 01:00 GMT.
+	now := time.Date(2026, 3, 23, 10, 15, 30, 0, london)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, london)
+	}
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2026-11-02 09:30", at(time.November, 2, 9, 30)},
+		{"2026-11-02T09:30", at(time.November, 2, 9, 30)},
+		{"2026-11-02 9am", at(time.November, 2, 9, 0)},
+		{"today 18:00", at(time.March, 23, 18, 0)},
+		{"tomorrow 9am", at(time.March, 24, 9, 0)},
+		{"Tomorrow at 9:30 PM", at(time.March, 24, 21, 30)},
+		{"friday 14:00", at(time.March, 27, 14, 0)},
+		{"fri 2pm", at(time.March, 27, 14, 0)},
+		// The current weekday means the one a week from now.
+		{"monday 9:00", at(time.March, 30, 9, 0)},
+		{"next monday 9:00", at(time.March, 30, 9, 0)},
+		{"next tuesday 9.30am", at(time.March, 24, 9, 30)},
+		{"18:00", at(time.March, 23, 18, 0)},
+		{"12am", at(time.March, 23, 0, 0)},
+		{"12pm", at(time.March, 23, 12, 0)},
+		{"12:30am", at(time.March, 23, 0, 30)},
+		{"noon", at(time.March, 23, 12, 0)},
+		{"tomorrow midnight", at(time.March, 24, 0, 0)},
+		{"+2h", at(time.March, 23, 12, 15)},
+		{"in 2 hours", at(time.March, 23, 12, 15)},
+		{"in 90m", at(time.March, 23, 11, 45)},
+		{"in 3 days", at(time.March, 26, 10, 15)},
+		// 01:30 on 29 March does not exist in London; it is moved forward
+		// an hour, to 02:30 BST.
+		{"2026-03-29 01:30", time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC)},
+		{"sunday 1:30am", time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := Parse(tt.value, now)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want.In(london))
+			}
+			if got.Location() != london {
+				t.Errorf("got location %s, want %s", got.Location(), london)
+			}
+		})
+	}
+}
+
+func TestParseDSTGapKeepsWallClockForward(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse("2026-03-29 01:30", time.Date(2026, 3, 23, 10, 0, 0, 0, london))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Hour() != 2 || got.Minute() != 30 {
+		t.Errorf("got %s, want 02:30 BST", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	now := time.Date(2026, 3, 23, 10, 0, 0, 0, time.UTC)
+	for _, value := range []string{
+		"",
+		"9",        // ambiguous without minutes or am/pm
+		"tomorrow", // a day without a time of day
+		"next june 9am",
+		"someday 9am",
+		"13pm",
+		"0am",
+		"25:00",
+		"9:75",
+		"in 2 fortnights",
+		"2026-02-30 09:00",
+	} {
+		if got, err := Parse(value, now); err == nil {
+			t.Errorf("Parse(%q) = %s, want an error", value, got)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"48h", 48 * time.Hour},
+		{"2d", 48 * time.Hour},
+		{"90 minutes", 90 * time.Minute},
+		{"1 week", 7 * 24 * time.Hour},
+		{" 30M ", 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.value)
+		if err != nil {
+			t.Errorf("ParseDuration(%q): %v", tt.value, err)
+		} else if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+	for _, value := range []string{"", "48", "h", "2 fortnights", "-1h", "1.5h"} {
+		if _, err := ParseDuration(value); err == nil {
+			t.Errorf("ParseDuration(%q): want an error", value)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package diff compares the configuration of two accounts, or of an account
+// and a backup snapshot: which fields, groups, segments, webhooks and forms
+// exist on one side only, and which properties of those on both sides
+// differ. Items are matched by name.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Kinds are the kinds of items compared, in order.
+var Kinds = []string{"fields", "groups", "segments", "webhooks", "forms"}
+
+// properties are the compared properties of each kind, besides the name.
+// Counts and timestamps differ between any two accounts and are ignored.
+var properties = map[string][]string{
+	"fields":   {"type"},
+	"groups":   {},
+	"segments": {},
+	"webhooks": {"url", "events", "enabled"},
+	"forms":    {"type"},
+}
+
+// Change kinds.
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Change is an item that differs between the two sides. Added items exist
+// only on the other side, removed items only on the base side.
+type Change struct {
+	Kind       string     `json:"kind"`
+	Name       string     `json:"name"`
+	Change     string     `json:"change"`
+	Properties []Property `json:"properties,omitempty"`
+}
+
+// Property is a property whose value differs.
+type Property struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Compare returns the changes from base to other for one kind of item,
+// given as the API returns them, sorted by name.
+func Compare(kind string, base, other []json.RawMessage) ([]Change, error) {
+	from, err := index(kind, base)
+	if err != nil {
+		return nil, err
+	}
+	to, err := index(kind, other)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []Change{}
+	for name, a := range from {
+		b, ok := to[name]
+		if !ok {
+			changes = append(changes, Change{Kind: kind, Name: name, Change: Removed})
+			continue
+		}
+		var props []Property
+		for _, p := range properties[kind] {
+			if a[p] != b[p] {
+				props = append(props, Property{Name: p, From: a[p], To: b[p]})
+			}
+		}
+		if len(props) > 0 {
+			changes = append(changes, Change{Kind: kind, Name: name, Change: Changed, Properties: props})
+		}
+	}
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			changes = append(changes, Change{Kind: kind, Name: name, Change: Added})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes, nil
+}
+
+// index maps each item's name to its compared properties as strings. Items
+// sharing a name are told apart by a " (2)", " (3)", ... suffix.
+func index(kind string, items []json.RawMessage) (map[string]map[string]string, error) {
+	m := make(map[string]map[string]string, len(items))
+	for _, raw := range items {
+		var item map[string]interface{}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", kind, err)
+		}
+		props := map[string]string{}
+		for _, p := range properties[kind] {
+			props[p] = value(item[p])
+		}
+
+		name := value(item["name"])
+		key := name
+		for n := 2; m[key] != nil; n++ {
+			key = fmt.Sprintf("%s (%d)", name, n)
+		}
+		m[key] = props
+	}
+	return m, nil
+}
+
+// value formats a property for comparison and display. Lists are compared
+// regardless of order.
+func value(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, e := range v {
+			values[i] = value(e)
+		}
+		slices.Sort(values)
+		return strings.Join(values, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
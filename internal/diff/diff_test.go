@@ -0,0 +1,84 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name  string
+		kind  string
+		base  []string
+		other []string
+		want  []Change
+	}{
+		{
+			name:  "identical",
+			kind:  "groups",
+			base:  []string{`{"id": "1", "name": "VIP", "active_count": 10}`},
+			other: []string{`{"id": "7", "name": "VIP", "active_count": 3}`},
+			want:  []Change{},
+		},
+		{
+			name:  "added and removed",
+			kind:  "groups",
+			base:  []string{`{"name": "Old"}`, `{"name": "Kept"}`},
+			other: []string{`{"name": "Kept"}`, `{"name": "New"}`},
+			want: []Change{
+				{Kind: "groups", Name: "New", Change: Added},
+				{Kind: "groups", Name: "Old", Change: Removed},
+			},
+		},
+		{
+			name:  "changed properties",
+			kind:  "webhooks",
+			base:  []string{`{"name": "CRM", "url": "https://a.example.com", "events": ["b", "a"], "enabled": true}`},
+			other: []string{`{"name": "CRM", "url": "https://b.example.com", "events": ["a", "b"], "enabled": false}`},
+			want: []Change{{Kind: "webhooks", Name: "CRM", Change: Changed, Properties: []Property{
+				{Name: "url", From: "https://a.example.com", To: "https://b.example.com"},
+				{Name: "enabled", From: "true", To: "false"},
+			}}},
+		},
+		{
+			name:  "field type",
+			kind:  "fields",
+			base:  []string{`{"name": "Plan", "type": "text"}`},
+			other: []string{`{"name": "Plan", "type": "number"}`},
+			want:  []Change{{Kind: "fields", Name: "Plan", Change: Changed, Properties: []Property{{Name: "type", From: "text", To: "number"}}}},
+		},
+		{
+			name:  "duplicate names",
+			kind:  "groups",
+			base:  []string{`{"name": "VIP"}`, `{"name": "VIP"}`},
+			other: []string{`{"name": "VIP"}`},
+			want:  []Change{{Kind: "groups", Name: "VIP (2)", Change: Removed}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compare(tt.kind, raw(tt.base), raw(tt.other))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareInvalidItem(t *testing.T) {
+	if _, err := Compare("groups", raw([]string{`[1]`}), nil); err == nil {
+		t.Error("got no error for an item that is not an object")
+	}
+}
+
+func raw(items []string) []json.RawMessage {
+	out := make([]json.RawMessage, len(items))
+	for i, s := range items {
+		out[i] = json.RawMessage(s)
+	}
+	return out
+}
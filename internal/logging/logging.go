@@ -0,0 +1,74 @@
+// Package logging builds the CLI's debug logger. Everything it writes passes
+// through Redact, so logs can be shared without leaking credentials or
+// subscriber email addresses.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Log formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Formats lists the supported log formats.
+var Formats = []string{FormatText, FormatJSON}
+
+// New returns a debug-level logger writing to w in the given format.
+func New(w io.Writer, format string) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: redactAttr}
+	switch format {
+	case FormatText, "":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	}
+	return nil, fmt.Errorf("invalid log format %q: use %s", format, strings.Join(Formats, " or "))
+}
+
+// secretKeys are attribute and JSON keys whose values are always redacted.
+var secretKeys = []string{"authorization", "token", "api_token", "access_token", "refresh_token", "oauth_token", "secret", "password"}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if isSecretKey(a.Key) {
+		return slog.String(a.Key, "REDACTED")
+	}
+	if a.Value.Kind() == slog.KindString {
+		return slog.String(a.Key, Redact(a.Value.String()))
+	}
+	return a
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range secretKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	emailPattern  = regexp.MustCompile(`([A-Za-z0-9._%+\-])[A-Za-z0-9._%+\-]*(@|%40)([A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[^\s"']+`)
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`)
+	// secretFieldPattern matches "key": "value" pairs of secret keys in JSON.
+	secretFieldPattern = regexp.MustCompile(`(?i)("(?:` + strings.Join(secretKeys, "|") + `)"\s*:\s*)"[^"]*"`)
+)
+
+// Redact masks bearer tokens, JWTs, secret JSON fields and email addresses
+// in s. Email addresses keep their first character and domain, e.g.
+// "j***@example.com", so log lines can still be told apart.
+func Redact(s string) string {
+	s = bearerPattern.ReplaceAllString(s, "${1}REDACTED")
+	s = jwtPattern.ReplaceAllString(s, "REDACTED")
+	s = secretFieldPattern.ReplaceAllString(s, `${1}"REDACTED"`)
+	return emailPattern.ReplaceAllString(s, "${1}***${2}${3}")
+}
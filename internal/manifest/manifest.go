@@ -0,0 +1,204 @@
+// Package manifest describes the configuration of a MailerLite account —
+// custom fields, groups, segments and webhooks — as a YAML document, and
+// plans and applies the changes that bring an account in line with one.
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest is the configuration of an account. Items are identified by name;
+// IDs are only known for items fetched from an account.
+//
+// A nil list means the manifest does not manage that kind of item at all,
+// while an empty list means the account should have none of them.
+type Manifest struct {
+	Fields   []Field   `yaml:"fields,omitempty" json:"fields,omitempty"`
+	Groups   []Group   `yaml:"groups,omitempty" json:"groups,omitempty"`
+	Segments []Segment `yaml:"segments,omitempty" json:"segments,omitempty"`
+	Webhooks []Webhook `yaml:"webhooks,omitempty" json:"webhooks,omitempty"`
+}
+
+// Field is a custom subscriber field. Key is the merge tag key the API
+// derives from the name; it is only known for fields fetched from an account.
+type Field struct {
+	ID   string `yaml:"-" json:"id,omitempty"`
+	Key  string `yaml:"-" json:"key,omitempty"`
+	Name string `yaml:"name" json:"name"`
+	Type string `yaml:"type" json:"type"`
+}
+
+// Group is a subscriber group.
+type Group struct {
+	ID   string `yaml:"-" json:"id,omitempty"`
+	Name string `yaml:"name" json:"name"`
+}
+
+// Segment is a subscriber segment. Segments are defined by filters that the
+// API does not expose, so a manifest can only require them to exist.
+type Segment struct {
+	ID   string `yaml:"-" json:"id,omitempty"`
+	Name string `yaml:"name" json:"name"`
+}
+
+// Webhook is a webhook. A nil Enabled means enabled when creating and
+// unmanaged when updating.
+type Webhook struct {
+	ID      string   `yaml:"-" json:"id,omitempty"`
+	Name    string   `yaml:"name" json:"name"`
+	URL     string   `yaml:"url" json:"url"`
+	Events  []string `yaml:"events" json:"events"`
+	Enabled *bool    `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+}
+
+// FieldTypes are the types a custom field can have.
+var FieldTypes = []string{"text", "number", "date"}
+
+// Load reads and validates a manifest from path, or stdin if path is "-".
+// Unknown keys are rejected so that typos do not silently go unmanaged.
+func Load(path string) (*Manifest, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close() //nolint:errcheck
+		r = f
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	m := &Manifest{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Validate checks that every item has a name that is unique within its kind
+// and the properties its kind requires.
+func (m *Manifest) Validate() error {
+	var errs []error
+	check := func(kind string, i int, name string, seen map[string]bool) {
+		switch {
+		case name == "":
+			errs = append(errs, fmt.Errorf("%s %d: name is required", kind, i+1))
+		case seen[name]:
+			errs = append(errs, fmt.Errorf("%s %q: listed more than once", kind, name))
+		}
+		seen[name] = true
+	}
+
+	seen := map[string]bool{}
+	for i, f := range m.Fields {
+		check("field", i, f.Name, seen)
+		if !slices.Contains(FieldTypes, f.Type) {
+			errs = append(errs, fmt.Errorf("field %q: type must be one of %v", f.Name, FieldTypes))
+		}
+	}
+	seen = map[string]bool{}
+	for i, g := range m.Groups {
+		check("group", i, g.Name, seen)
+	}
+	seen = map[string]bool{}
+	for i, s := range m.Segments {
+		check("segment", i, s.Name, seen)
+	}
+	seen = map[string]bool{}
+	for i, w := range m.Webhooks {
+		check("webhook", i, w.Name, seen)
+		if w.URL == "" {
+			errs = append(errs, fmt.Errorf("webhook %q: url is required", w.Name))
+		}
+		if len(w.Events) == 0 {
+			errs = append(errs, fmt.Errorf("webhook %q: at least one event is required", w.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Fetch returns the current configuration of the account behind ml.
+func Fetch(ctx context.Context, ml *mailerlite.Client) (*Manifest, error) {
+	m := &Manifest{}
+
+	fields, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Field, bool, error) {
+		root, _, err := ml.Field.List(ctx, &mailerlite.ListFieldOptions{Page: page, Limit: perPage})
+		if err != nil {
+			return nil, false, sdkclient.WrapError(err)
+		}
+		return root.Data, !root.Links.IsLastPage(), nil
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fields: %w", err)
+	}
+	m.Fields = []Field{}
+	for _, f := range fields {
+		m.Fields = append(m.Fields, Field{ID: f.Id, Key: f.Key, Name: f.Name, Type: f.Type})
+	}
+
+	groups, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Group, bool, error) {
+		root, _, err := ml.Group.List(ctx, &mailerlite.ListGroupOptions{Page: page, Limit: perPage})
+		if err != nil {
+			return nil, false, sdkclient.WrapError(err)
+		}
+		return root.Data, !root.Links.IsLastPage(), nil
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	m.Groups = []Group{}
+	for _, g := range groups {
+		m.Groups = append(m.Groups, Group{ID: g.ID, Name: g.Name})
+	}
+
+	segments, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Segment, bool, error) {
+		root, _, err := ml.Segment.List(ctx, &mailerlite.ListSegmentOptions{Page: page, Limit: perPage})
+		if err != nil {
+			return nil, false, sdkclient.WrapError(err)
+		}
+		return root.Data, !root.Links.IsLastPage(), nil
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list segments: %w", err)
+	}
+	m.Segments = []Segment{}
+	for _, s := range segments {
+		m.Segments = append(m.Segments, Segment{ID: s.ID, Name: s.Name})
+	}
+
+	webhooks, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Webhook, bool, error) {
+		root, _, err := ml.Webhook.List(ctx, &mailerlite.ListWebhookOptions{Page: page, Limit: perPage})
+		if err != nil {
+			return nil, false, sdkclient.WrapError(err)
+		}
+		return root.Data, !root.Links.IsLastPage(), nil
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	m.Webhooks = []Webhook{}
+	for _, w := range webhooks {
+		enabled := w.Enabled
+		m.Webhooks = append(m.Webhooks, Webhook{ID: w.Id, Name: w.Name, URL: w.Url, Events: w.Events, Enabled: &enabled})
+	}
+
+	return m, nil
+}
@@ -0,0 +1,301 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+)
+
+// Action is what a Change does to an item.
+type Action string
+
+// Change actions.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is one API call that brings an item closer to the manifest. Details
+// describe the properties being set or changed, e.g. `url: "a" -> "b"`.
+type Change struct {
+	Action  Action   `json:"action"`
+	Kind    string   `json:"kind"`
+	Name    string   `json:"name"`
+	ID      string   `json:"id,omitempty"`
+	Details []string `json:"details,omitempty"`
+
+	do func(ctx context.Context, ml *mailerlite.Client) error
+}
+
+// Plan is the list of changes needed to converge an account on a manifest.
+// Problems are differences that cannot be resolved through the API, such as
+// changing a field's type; a plan with problems must not be applied.
+type Plan struct {
+	Changes  []Change `json:"changes"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Count returns the number of changes with the given action.
+func (p *Plan) Count(action Action) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// NewPlan compares the desired manifest with the current state of an account
+// (as returned by Fetch) and returns the changes that converge them. Items
+// missing from desired are deleted only if prune is set, and only for kinds
+// desired lists at all.
+func NewPlan(desired, current *Manifest, prune bool) *Plan {
+	p := &Plan{Changes: []Change{}}
+	p.planFields(desired.Fields, current.Fields, prune && desired.Fields != nil)
+	p.planGroups(desired.Groups, current.Groups, prune && desired.Groups != nil)
+	p.planSegments(desired.Segments, current.Segments, prune && desired.Segments != nil)
+	p.planWebhooks(desired.Webhooks, current.Webhooks, prune && desired.Webhooks != nil)
+	return p
+}
+
+// Apply makes the changes in order and stops at the first failure. It
+// returns the number of changes made.
+func (p *Plan) Apply(ctx context.Context, ml *mailerlite.Client) (int, error) {
+	if len(p.Problems) > 0 {
+		return 0, fmt.Errorf("the plan has %d unresolved problem(s)", len(p.Problems))
+	}
+	for i, c := range p.Changes {
+		if err := c.Apply(ctx, ml); err != nil {
+			return i, err
+		}
+	}
+	return len(p.Changes), nil
+}
+
+// Apply makes the change.
+func (c Change) Apply(ctx context.Context, ml *mailerlite.Client) error {
+	if err := c.do(ctx, ml); err != nil {
+		return fmt.Errorf("failed to %s %s %q: %w", c.Action, c.Kind, c.Name, err)
+	}
+	return nil
+}
+
+// byName indexes items by name. Names are not unique in every account, so
+// each name maps to all items that have it.
+func byName[T any](items []T, name func(T) string) map[string][]T {
+	m := make(map[string][]T, len(items))
+	for _, item := range items {
+		m[name(item)] = append(m[name(item)], item)
+	}
+	return m
+}
+
+// match returns the current item named name. If the account has several, a
+// problem is recorded and ok is false.
+func match[T any](p *Plan, kind, name string, index map[string][]T) (item T, found, ok bool) {
+	items := index[name]
+	switch len(items) {
+	case 0:
+		return item, false, true
+	case 1:
+		return items[0], true, true
+	default:
+		p.Problems = append(p.Problems, fmt.Sprintf("%s %q exists %d times in the account; remove the duplicates first", kind, name, len(items)))
+		return item, true, false
+	}
+}
+
+// defaultFieldKeys are the keys of the fields every account has. The API
+// refuses to delete them, so they are neither pruned nor compared.
+var defaultFieldKeys = []string{"name", "last_name", "company", "country", "city", "phone", "state", "z_i_p"}
+
+func isDefaultField(f Field) bool {
+	return slices.Contains(defaultFieldKeys, f.Key)
+}
+
+func (p *Plan) planFields(desired, current []Field, prune bool) {
+	index := byName(current, func(f Field) string { return f.Name })
+	for _, want := range desired {
+		have, found, ok := match(p, "field", want.Name, index)
+		switch {
+		case !ok:
+		case found && isDefaultField(have):
+		case !found:
+			p.Changes = append(p.Changes, Change{
+				Action: ActionCreate, Kind: "field", Name: want.Name,
+				Details: []string{"type: " + want.Type},
+				do: func(ctx context.Context, ml *mailerlite.Client) error {
+					_, _, err := ml.Field.Create(ctx, want.Name, want.Type)
+					return sdkclient.WrapError(err)
+				},
+			})
+		case have.Type != want.Type:
+			p.Problems = append(p.Problems, fmt.Sprintf("field %q has type %s, not %s; a field's type cannot be changed", want.Name, have.Type, want.Type))
+		}
+	}
+	if prune {
+		wanted := byName(desired, func(f Field) string { return f.Name })
+		for _, have := range current {
+			if _, ok := wanted[have.Name]; !ok && !isDefaultField(have) {
+				p.Changes = append(p.Changes, Change{
+					Action: ActionDelete, Kind: "field", Name: have.Name, ID: have.ID,
+					do: func(ctx context.Context, ml *mailerlite.Client) error {
+						_, err := ml.Field.Delete(ctx, have.ID)
+						return sdkclient.WrapError(err)
+					},
+				})
+			}
+		}
+	}
+}
+
+func (p *Plan) planGroups(desired, current []Group, prune bool) {
+	index := byName(current, func(g Group) string { return g.Name })
+	for _, want := range desired {
+		if _, found, ok := match(p, "group", want.Name, index); ok && !found {
+			p.Changes = append(p.Changes, Change{
+				Action: ActionCreate, Kind: "group", Name: want.Name,
+				do: func(ctx context.Context, ml *mailerlite.Client) error {
+					_, _, err := ml.Group.Create(ctx, want.Name)
+					return sdkclient.WrapError(err)
+				},
+			})
+		}
+	}
+	if prune {
+		wanted := byName(desired, func(g Group) string { return g.Name })
+		for _, have := range current {
+			if _, ok := wanted[have.Name]; !ok {
+				p.Changes = append(p.Changes, Change{
+					Action: ActionDelete, Kind: "group", Name: have.Name, ID: have.ID,
+					do: func(ctx context.Context, ml *mailerlite.Client) error {
+						_, err := ml.Group.Delete(ctx, have.ID)
+						return sdkclient.WrapError(err)
+					},
+				})
+			}
+		}
+	}
+}
+
+func (p *Plan) planSegments(desired, current []Segment, prune bool) {
+	index := byName(current, func(s Segment) string { return s.Name })
+	for _, want := range desired {
+		if _, found, ok := match(p, "segment", want.Name, index); ok && !found {
+			p.Problems = append(p.Problems, fmt.Sprintf("segment %q does not exist; segments cannot be created through the API", want.Name))
+		}
+	}
+	if prune {
+		wanted := byName(desired, func(s Segment) string { return s.Name })
+		for _, have := range current {
+			if _, ok := wanted[have.Name]; !ok {
+				p.Changes = append(p.Changes, Change{
+					Action: ActionDelete, Kind: "segment", Name: have.Name, ID: have.ID,
+					do: func(ctx context.Context, ml *mailerlite.Client) error {
+						_, err := ml.Segment.Delete(ctx, have.ID)
+						return sdkclient.WrapError(err)
+					},
+				})
+			}
+		}
+	}
+}
+
+func (p *Plan) planWebhooks(desired, current []Webhook, prune bool) {
+	index := byName(current, func(w Webhook) string { return w.Name })
+	for _, want := range desired {
+		have, found, ok := match(p, "webhook", want.Name, index)
+		if !ok {
+			continue
+		}
+		if !found {
+			details := []string{"url: " + want.URL, "events: " + strings.Join(want.Events, ", ")}
+			if want.Enabled != nil {
+				details = append(details, "enabled: "+strconv.FormatBool(*want.Enabled))
+			}
+			p.Changes = append(p.Changes, Change{
+				Action: ActionCreate, Kind: "webhook", Name: want.Name, Details: details,
+				do: func(ctx context.Context, ml *mailerlite.Client) error {
+					created, _, err := ml.Webhook.Create(ctx, &mailerlite.CreateWebhookOptions{Name: want.Name, Url: want.URL, Events: want.Events})
+					if err != nil {
+						return sdkclient.WrapError(err)
+					}
+					// New webhooks start enabled; the API has no way to create a disabled one.
+					if want.Enabled != nil && !*want.Enabled {
+						_, _, err = ml.Webhook.Update(ctx, &mailerlite.UpdateWebhookOptions{WebhookID: created.Data.Id, Enabled: "false"})
+					}
+					return sdkclient.WrapError(err)
+				},
+			})
+			continue
+		}
+
+		opts := &mailerlite.UpdateWebhookOptions{WebhookID: have.ID}
+		var details []string
+		if have.URL != want.URL {
+			opts.Url = want.URL
+			details = append(details, fmt.Sprintf("url: %q -> %q", have.URL, want.URL))
+		}
+		if added, removed := setDiff(have.Events, want.Events); len(added)+len(removed) > 0 {
+			opts.Events = want.Events
+			var diff []string
+			for _, e := range added {
+				diff = append(diff, "+"+e)
+			}
+			for _, e := range removed {
+				diff = append(diff, "-"+e)
+			}
+			details = append(details, "events: "+strings.Join(diff, ", "))
+		}
+		if want.Enabled != nil && have.Enabled != nil && *have.Enabled != *want.Enabled {
+			opts.Enabled = strconv.FormatBool(*want.Enabled)
+			details = append(details, fmt.Sprintf("enabled: %t -> %t", *have.Enabled, *want.Enabled))
+		}
+		if len(details) > 0 {
+			p.Changes = append(p.Changes, Change{
+				Action: ActionUpdate, Kind: "webhook", Name: want.Name, ID: have.ID, Details: details,
+				do: func(ctx context.Context, ml *mailerlite.Client) error {
+					_, _, err := ml.Webhook.Update(ctx, opts)
+					return sdkclient.WrapError(err)
+				},
+			})
+		}
+	}
+	if prune {
+		wanted := byName(desired, func(w Webhook) string { return w.Name })
+		for _, have := range current {
+			if _, ok := wanted[have.Name]; !ok {
+				p.Changes = append(p.Changes, Change{
+					Action: ActionDelete, Kind: "webhook", Name: have.Name, ID: have.ID,
+					do: func(ctx context.Context, ml *mailerlite.Client) error {
+						_, err := ml.Webhook.Delete(ctx, have.ID)
+						return sdkclient.WrapError(err)
+					},
+				})
+			}
+		}
+	}
+}
+
+// setDiff returns the values in want but not in have, and those in have but
+// not in want, in order.
+func setDiff(have, want []string) (added, removed []string) {
+	for _, v := range want {
+		if !slices.Contains(have, v) {
+			added = append(added, v)
+		}
+	}
+	for _, v := range have {
+		if !slices.Contains(want, v) {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,112 @@
+package manifest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewPlan(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name     string
+		desired  Manifest
+		current  Manifest
+		prune    bool
+		changes  []string
+		problems []string
+	}{
+		{
+			name:    "creates missing items",
+			desired: Manifest{Fields: []Field{{Name: "Plan", Type: "text"}}, Groups: []Group{{Name: "VIP"}}},
+			changes: []string{"create field Plan (type: text)", "create group VIP"},
+		},
+		{
+			name:    "leaves matching items alone",
+			desired: Manifest{Fields: []Field{{Name: "Plan", Type: "text"}}, Groups: []Group{{Name: "VIP"}}},
+			current: Manifest{Fields: []Field{{ID: "1", Key: "plan", Name: "Plan", Type: "text"}}, Groups: []Group{{ID: "2", Name: "VIP"}}},
+		},
+		{
+			name:    "matches names exactly",
+			desired: Manifest{Groups: []Group{{Name: "VIP"}}},
+			current: Manifest{Groups: []Group{{ID: "2", Name: "vip"}}},
+			changes: []string{"create group VIP"},
+		},
+		{
+			name:     "reports duplicate names",
+			desired:  Manifest{Groups: []Group{{Name: "VIP"}}},
+			current:  Manifest{Groups: []Group{{ID: "2", Name: "VIP"}, {ID: "3", Name: "VIP"}}},
+			problems: []string{`group "VIP" exists 2 times in the account; remove the duplicates first`},
+		},
+		{
+			name:     "reports a changed field type",
+			desired:  Manifest{Fields: []Field{{Name: "Plan", Type: "number"}}},
+			current:  Manifest{Fields: []Field{{ID: "1", Key: "plan", Name: "Plan", Type: "text"}}},
+			problems: []string{`field "Plan" has type text, not number; a field's type cannot be changed`},
+		},
+		{
+			name:    "never compares default fields",
+			desired: Manifest{Fields: []Field{{Name: "City", Type: "number"}}},
+			current: Manifest{Fields: []Field{{ID: "1", Key: "city", Name: "City", Type: "text"}}},
+		},
+		{
+			name:     "reports missing segments",
+			desired:  Manifest{Segments: []Segment{{Name: "Engaged"}}},
+			problems: []string{`segment "Engaged" does not exist; segments cannot be created through the API`},
+		},
+		{
+			name:    "updates changed webhooks",
+			desired: Manifest{Webhooks: []Webhook{{Name: "CRM", URL: "https://b.example.com", Events: []string{"subscriber.created", "subscriber.updated"}, Enabled: &disabled}}},
+			current: Manifest{Webhooks: []Webhook{{ID: "9", Name: "CRM", URL: "https://a.example.com", Events: []string{"subscriber.created", "subscriber.deleted"}, Enabled: &enabled}}},
+			changes: []string{`update webhook CRM (url: "https://a.example.com" -> "https://b.example.com"; events: +subscriber.updated, -subscriber.deleted; enabled: true -> false)`},
+		},
+		{
+			name:    "does not delete without prune",
+			desired: Manifest{Groups: []Group{}},
+			current: Manifest{Groups: []Group{{ID: "2", Name: "VIP"}}},
+		},
+		{
+			name:    "prunes listed kinds only",
+			desired: Manifest{Groups: []Group{}},
+			current: Manifest{Groups: []Group{{ID: "2", Name: "VIP"}}, Segments: []Segment{{ID: "3", Name: "Engaged"}}},
+			prune:   true,
+			changes: []string{"delete group VIP"},
+		},
+		{
+			name:    "never prunes default fields",
+			desired: Manifest{Fields: []Field{{Name: "Plan", Type: "text"}}},
+			current: Manifest{Fields: []Field{
+				{ID: "1", Key: "name", Name: "Name", Type: "text"},
+				{ID: "2", Key: "z_i_p", Name: "ZIP", Type: "text"},
+				{ID: "3", Key: "plan", Name: "Plan", Type: "text"},
+				{ID: "4", Key: "old", Name: "Old", Type: "text"},
+			}},
+			prune:   true,
+			changes: []string{"delete field Old"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlan(&tt.desired, &tt.current, tt.prune)
+			if got := describe(p.Changes); !reflect.DeepEqual(got, tt.changes) {
+				t.Errorf("changes:\n got %q\nwant %q", got, tt.changes)
+			}
+			if !reflect.DeepEqual(p.Problems, tt.problems) {
+				t.Errorf("problems:\n got %q\nwant %q", p.Problems, tt.problems)
+			}
+		})
+	}
+}
+
+// describe summarises changes as "action kind name (details)".
+func describe(changes []Change) []string {
+	var out []string
+	for _, c := range changes {
+		s := string(c.Action) + " " + c.Kind + " " + c.Name
+		if len(c.Details) > 0 {
+			s += " (" + strings.Join(c.Details, "; ") + ")"
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,308 @@
+// Package migrate copies custom fields, groups and subscribers from one
+// account to another. Fields and groups are matched by name, subscribers by
+// email, and the progress of the subscriber copy is kept in a state file so
+// that an interrupted migration can be resumed.
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mailerlite/mailerlite-cli/internal/bulk"
+	"github.com/mailerlite/mailerlite-cli/internal/manifest"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+)
+
+// Resources are the resources that can be migrated, in order.
+var Resources = []string{"fields", "groups", "subscribers"}
+
+// pageSize is the number of subscribers read from the source per request,
+// and the unit in which progress is saved.
+const pageSize = 100
+
+// Account is one side of a migration.
+type Account struct {
+	SDK    *mailerlite.Client
+	HTTP   *http.Client
+	APIKey string
+}
+
+// Options control a migration.
+type Options struct {
+	Resources   []string
+	Concurrency int
+	// Progress, if set, receives a running count of migrated subscribers.
+	Progress io.Writer
+}
+
+// State is the progress of a migration, saved after every page of
+// subscribers. Fields and Groups record how source field keys and group IDs
+// were mapped to the target account.
+type State struct {
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	Fields    map[string]string `json:"fields"`
+	Groups    map[string]string `json:"groups"`
+	Cursor    string            `json:"cursor,omitempty"`
+	Done      bool              `json:"done"`
+	Migrated  int               `json:"migrated"`
+	Failed    map[string]string `json:"failed,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ReadState reads the state of an interrupted migration from path.
+func ReadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("nothing to resume: %s not found", path)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	s := &State{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the state to path, replacing it atomically.
+func (s *State) Save(path string) error {
+	s.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Summary is the outcome of a migration run.
+type Summary struct {
+	FieldsCreated int               `json:"fields_created"`
+	FieldsMapped  int               `json:"fields_mapped"`
+	GroupsCreated int               `json:"groups_created"`
+	GroupsMapped  int               `json:"groups_mapped"`
+	Migrated      int               `json:"subscribers_migrated"`
+	Failed        map[string]string `json:"subscribers_failed,omitempty"`
+	Problems      []string          `json:"problems,omitempty"`
+}
+
+// Run migrates the selected resources from one account to the other,
+// resuming the subscriber copy from state and saving state to statePath as
+// it goes. Subscribers that failed in an earlier run are retried.
+func Run(ctx context.Context, from, to Account, state *State, statePath string, opts Options) (*Summary, error) {
+	includes := func(r string) bool { return opts.Resources == nil || slices.Contains(opts.Resources, r) }
+	summary := &Summary{}
+
+	source, err := manifest.Fetch(ctx, from.SDK)
+	if err != nil {
+		return summary, fmt.Errorf("source account: %w", err)
+	}
+	target, err := manifest.Fetch(ctx, to.SDK)
+	if err != nil {
+		return summary, fmt.Errorf("target account: %w", err)
+	}
+
+	// Create the missing fields and groups, then map them by name.
+	desired := &manifest.Manifest{}
+	if includes("fields") {
+		desired.Fields = source.Fields
+	}
+	if includes("groups") {
+		desired.Groups = source.Groups
+	}
+	plan := manifest.NewPlan(desired, target, false)
+	summary.Problems = plan.Problems
+	for _, change := range plan.Changes {
+		if err := change.Apply(ctx, to.SDK); err != nil {
+			return summary, err
+		}
+		switch change.Kind {
+		case "field":
+			summary.FieldsCreated++
+		case "group":
+			summary.GroupsCreated++
+		}
+	}
+	if len(plan.Changes) > 0 {
+		if target, err = manifest.Fetch(ctx, to.SDK); err != nil {
+			return summary, fmt.Errorf("target account: %w", err)
+		}
+	}
+	state.Fields, state.Groups = mapFields(source, target), mapGroups(source, target)
+	summary.FieldsMapped, summary.GroupsMapped = len(state.Fields), len(state.Groups)
+	if err := state.Save(statePath); err != nil {
+		return summary, err
+	}
+
+	if includes("subscribers") {
+		err = migrateSubscribers(ctx, from, to, state, statePath, opts)
+	}
+	summary.Migrated, summary.Failed = state.Migrated, state.Failed
+	return summary, err
+}
+
+func mapFields(source, target *manifest.Manifest) map[string]string {
+	keys := map[string]string{}
+	for _, t := range target.Fields {
+		for _, s := range source.Fields {
+			if s.Name == t.Name && s.Type == t.Type {
+				keys[s.Key] = t.Key
+			}
+		}
+	}
+	return keys
+}
+
+func mapGroups(source, target *manifest.Manifest) map[string]string {
+	ids := map[string]string{}
+	for _, t := range target.Groups {
+		for _, s := range source.Groups {
+			if s.Name == t.Name {
+				ids[s.ID] = t.ID
+			}
+		}
+	}
+	return ids
+}
+
+// subscriber is the part of a source subscriber that is migrated.
+type subscriber struct {
+	Email  string                 `json:"email"`
+	Status string                 `json:"status"`
+	Fields map[string]interface{} `json:"fields"`
+	Groups []struct {
+		ID string `json:"id"`
+	} `json:"groups"`
+}
+
+// body is the target upsert request for sub, with field keys and group IDs
+// translated through the state's mappings. Unmapped ones are dropped.
+func (sub subscriber) body(state *State) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for key, v := range sub.Fields {
+		if target := state.Fields[key]; target != "" && v != nil {
+			fields[target] = v
+		}
+	}
+	groups := []string{}
+	for _, g := range sub.Groups {
+		if target := state.Groups[g.ID]; target != "" {
+			groups = append(groups, target)
+		}
+	}
+	body := map[string]interface{}{"email": sub.Email, "fields": fields, "groups": groups}
+	if sub.Status != "" {
+		body["status"] = sub.Status
+	}
+	return body
+}
+
+func migrateSubscribers(ctx context.Context, from, to Account, state *State, statePath string, opts Options) error {
+	// Retry the failures of earlier runs first.
+	if len(state.Failed) > 0 {
+		var subs []subscriber
+		for email := range state.Failed {
+			var res struct {
+				Data subscriber `json:"data"`
+			}
+			if _, err := sdkclient.DoRaw(ctx, from.HTTP, from.APIKey, http.MethodGet, "/subscribers/"+url.PathEscape(email)+"?include=groups", nil, &res); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				state.Failed[email] = flatten(err)
+				continue
+			}
+			subs = append(subs, res.Data)
+		}
+		if err := upsert(ctx, to, subs, state, opts); err != nil {
+			return err
+		}
+		if err := state.Save(statePath); err != nil {
+			return err
+		}
+	}
+
+	for !state.Done {
+		path := fmt.Sprintf("/subscribers?limit=%d&include=groups", pageSize)
+		if state.Cursor != "" {
+			path += "&cursor=" + url.QueryEscape(state.Cursor)
+		}
+		var page struct {
+			Data []subscriber `json:"data"`
+			Meta struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"meta"`
+		}
+		if _, err := sdkclient.DoRaw(ctx, from.HTTP, from.APIKey, http.MethodGet, path, nil, &page); err != nil {
+			return fmt.Errorf("failed to list source subscribers: %w", err)
+		}
+
+		// The cursor only moves on once the whole page is done, so an
+		// interrupted page is redone on resume; upserts make that harmless.
+		if err := upsert(ctx, to, page.Data, state, opts); err != nil {
+			return err
+		}
+		state.Cursor = page.Meta.NextCursor
+		state.Done = state.Cursor == "" || len(page.Data) == 0
+		if err := state.Save(statePath); err != nil {
+			return err
+		}
+	}
+	if opts.Progress != nil {
+		fmt.Fprintln(opts.Progress) //nolint:errcheck
+	}
+	return nil
+}
+
+// upsert creates or updates subs in the target account and records the
+// outcome of each in state. State is saved by the caller, so that an
+// interrupted page is counted only once it is redone.
+func upsert(ctx context.Context, to Account, subs []subscriber, state *State, opts Options) error {
+	bodies := make(map[string]map[string]interface{}, len(subs))
+	emails := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		if _, dup := bodies[sub.Email]; !dup {
+			emails = append(emails, sub.Email)
+		}
+		bodies[sub.Email] = sub.body(state)
+	}
+
+	summary, runErr := bulk.Run(ctx, emails, bulk.Options{Concurrency: opts.Concurrency}, func(ctx context.Context, email string) error {
+		_, err := sdkclient.DoRaw(ctx, to.HTTP, to.APIKey, http.MethodPost, "/subscribers", bodies[email], nil)
+		return err
+	})
+	for _, res := range summary.Results {
+		if res.Status == bulk.StatusOK {
+			delete(state.Failed, res.Item)
+			state.Migrated++
+			continue
+		}
+		if state.Failed == nil {
+			state.Failed = map[string]string{}
+		}
+		state.Failed[res.Item] = res.Error
+	}
+	if opts.Progress != nil {
+		fmt.Fprintf(opts.Progress, "\rMigrated %d subscribers (%d failed)", state.Migrated, len(state.Failed)) //nolint:errcheck
+	}
+	return runErr
+}
+
+func flatten(err error) string {
+	return strings.Join(strings.Fields(err.Error()), " ")
+}
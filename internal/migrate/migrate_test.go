@@ -0,0 +1,160 @@
+package migrate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mailerlite/mailerlite-cli/internal/manifest"
+	"github.com/mailerlite/mailerlite-cli/internal/mockapi"
+	"github.com/mailerlite/mailerlite-cli/internal/sdkclient"
+	"github.com/mailerlite/mailerlite-go"
+)
+
+// mockAccount serves a mock API for the duration of the test and returns an
+// account that talks to it.
+func mockAccount(t *testing.T, opts mockapi.Options) Account {
+	t.Helper()
+	srv := httptest.NewServer(mockapi.New(opts))
+	t.Cleanup(srv.Close)
+	httpClient := &http.Client{Transport: &sdkclient.CLITransport{BaseURL: srv.URL + "/api"}}
+	ml := mailerlite.NewClient("test")
+	ml.SetHttpClient(httpClient)
+	return Account{SDK: ml, HTTP: httpClient, APIKey: "test"}
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	from := mockAccount(t, mockapi.Options{})
+	to := mockAccount(t, mockapi.Options{Subscribers: 1})
+	if _, _, err := from.SDK.Field.Create(ctx, "Plan", "text"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := from.SDK.Group.Create(ctx, "Migrated"); err != nil {
+		t.Fatal(err)
+	}
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	state := &State{}
+	summary, err := Run(ctx, from, to, state, statePath, Options{Concurrency: 4})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if summary.FieldsCreated != 1 || summary.GroupsCreated != 1 {
+		t.Errorf("created %d fields and %d groups, want 1 and 1", summary.FieldsCreated, summary.GroupsCreated)
+	}
+	if summary.Migrated != 150 || len(summary.Failed) != 0 {
+		t.Errorf("migrated %d subscribers with %d failures, want 150 and none", summary.Migrated, len(summary.Failed))
+	}
+
+	target, err := manifest.Fetch(ctx, to.SDK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasGroup(target, "Migrated") {
+		t.Error("group Migrated was not created in the target account")
+	}
+	count, _, err := to.SDK.Subscriber.Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both mocks seed the same first subscriber, which is updated.
+	if count.Total != 150 {
+		t.Errorf("target has %d subscribers, want 150", count.Total)
+	}
+
+	// A finished migration is not redone when resumed.
+	saved, err := ReadState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.Done || saved.Migrated != 150 {
+		t.Fatalf("saved state: done %t, migrated %d; want done, 150", saved.Done, saved.Migrated)
+	}
+	summary, err = Run(ctx, from, to, saved, statePath, Options{Concurrency: 4})
+	if err != nil {
+		t.Fatalf("resumed Run: %v", err)
+	}
+	if summary.Migrated != 150 || summary.FieldsCreated+summary.GroupsCreated != 0 {
+		t.Errorf("resumed run migrated %d and created %d items, want 150 and none", summary.Migrated, summary.FieldsCreated+summary.GroupsCreated)
+	}
+}
+
+func hasGroup(m *manifest.Manifest, name string) bool {
+	for _, g := range m.Groups {
+		if g.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSubscriberBody(t *testing.T) {
+	state := &State{
+		Fields: map[string]string{"name": "name", "plan": "plan_2"},
+		Groups: map[string]string{"1": "101"},
+	}
+	tests := []struct {
+		name string
+		sub  subscriber
+		want map[string]interface{}
+	}{
+		{
+			name: "maps fields and groups",
+			sub: subscriber{
+				Email: "a@example.com", Status: "active",
+				Fields: map[string]interface{}{"name": "Ann", "plan": "pro"},
+				Groups: []struct {
+					ID string `json:"id"`
+				}{{ID: "1"}},
+			},
+			want: map[string]interface{}{
+				"email": "a@example.com", "status": "active",
+				"fields": map[string]interface{}{"name": "Ann", "plan_2": "pro"},
+				"groups": []string{"101"},
+			},
+		},
+		{
+			name: "drops unmapped and empty values",
+			sub: subscriber{
+				Email:  "b@example.com",
+				Fields: map[string]interface{}{"name": nil, "unknown": "x"},
+				Groups: []struct {
+					ID string `json:"id"`
+				}{{ID: "2"}},
+			},
+			want: map[string]interface{}{
+				"email":  "b@example.com",
+				"fields": map[string]interface{}{},
+				"groups": []string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sub.body(state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v\nwant %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapFields(t *testing.T) {
+	source := &manifest.Manifest{Fields: []manifest.Field{
+		{Key: "plan", Name: "Plan", Type: "text"},
+		{Key: "score", Name: "Score", Type: "number"},
+		{Key: "gone", Name: "Gone", Type: "text"},
+	}}
+	target := &manifest.Manifest{Fields: []manifest.Field{
+		{Key: "plan_1", Name: "Plan", Type: "text"},
+		{Key: "score", Name: "Score", Type: "text"},
+	}}
+	// Fields are matched by name and type.
+	want := map[string]string{"plan": "plan_1"}
+	if got := mapFields(source, target); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,467 @@
+package mockapi
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var subscriberStatuses = []string{"active", "unsubscribed", "unconfirmed", "bounced", "junk"}
+
+// --- subscribers ---
+
+func (s *Server) subscriberRoutes() {
+	s.handle("GET /subscribers", func(req *request) (int, interface{}) {
+		items, errResp := filterRecords(req, s.coll("subscribers").items, "status", "email")
+		if errResp != nil {
+			return http.StatusUnprocessableEntity, errResp
+		}
+		return paginateCursor(req, s.subscriberViews(items))
+	})
+	s.handle("GET /subscribers/{id}", func(req *request) (int, interface{}) {
+		rec := s.findSubscriber(req.PathValue("id"))
+		if rec == nil {
+			return notFound()
+		}
+		return http.StatusOK, data(s.subscriberView(rec))
+	})
+	s.handle("POST /subscribers", s.upsertSubscriber)
+	s.handle("PUT /subscribers/{id}", func(req *request) (int, interface{}) {
+		rec := s.coll("subscribers").find(req.PathValue("id"))
+		if rec == nil {
+			return notFound()
+		}
+		v := &validation{}
+		s.validateSubscriber(v, req.body, false)
+		if v.failed() {
+			return v.response()
+		}
+		s.applySubscriber(rec, req.body)
+		return http.StatusOK, data(s.subscriberView(rec))
+	})
+	s.handle("DELETE /subscribers/{id}", func(req *request) (int, interface{}) {
+		return s.delete("subscribers", req.PathValue("id"))
+	})
+	s.handle("POST /subscribers/{id}/forget", func(req *request) (int, interface{}) {
+		rec := s.coll("subscribers").find(req.PathValue("id"))
+		if rec == nil {
+			return notFound()
+		}
+		s.coll("subscribers").remove(req.PathValue("id"))
+		return http.StatusOK, map[string]interface{}{
+			"data":    s.subscriberView(rec),
+			"message": "Subscriber data will be completely deleted and forgotten within 30 days.",
+		}
+	})
+	s.handle("POST /subscribers/{id}/groups/{group}", func(req *request) (int, interface{}) {
+		rec := s.coll("subscribers").find(req.PathValue("id"))
+		group := s.coll("groups").find(req.PathValue("group"))
+		if rec == nil || group == nil {
+			return notFound()
+		}
+		addGroups(rec, group["id"])
+		return http.StatusOK, data(s.groupView(group))
+	})
+	s.handle("DELETE /subscribers/{id}/groups/{group}", func(req *request) (int, interface{}) {
+		rec := s.coll("subscribers").find(req.PathValue("id"))
+		if rec == nil || s.coll("groups").find(req.PathValue("group")) == nil {
+			return notFound()
+		}
+		removeGroup(rec, req.PathValue("group"))
+		return http.StatusNoContent, nil
+	})
+}
+
+// findSubscriber looks a subscriber up by ID or email address.
+func (s *Server) findSubscriber(idOrEmail string) record {
+	for _, rec := range s.coll("subscribers").items {
+		if rec["id"] == idOrEmail || strings.EqualFold(stringOf(rec["email"]), idOrEmail) {
+			return rec
+		}
+	}
+	return nil
+}
+
+// upsertSubscriber creates a subscriber, or updates the one with the same
+// email address.
+func (s *Server) upsertSubscriber(req *request) (int, interface{}) {
+	v := &validation{}
+	s.validateSubscriber(v, req.body, true)
+	if v.failed() {
+		return v.response()
+	}
+	if rec := s.findSubscriber(stringOf(req.body["email"])); rec != nil {
+		s.applySubscriber(rec, req.body)
+		return http.StatusOK, data(s.subscriberView(rec))
+	}
+	rec := s.insert("subscribers", s.newSubscriber(req.body))
+	return http.StatusCreated, data(s.subscriberView(rec))
+}
+
+func (s *Server) validateSubscriber(v *validation, body record, create bool) {
+	v.email(body, "email", create)
+	v.oneOf(body, "status", false, subscriberStatuses...)
+	if f, ok := body["fields"]; ok && f != nil {
+		if _, ok := f.(map[string]interface{}); !ok {
+			v.add("fields", "The fields must be an object.")
+		}
+	}
+	if g, ok := body["groups"]; ok && g != nil {
+		ids, ok := g.([]interface{})
+		if !ok {
+			v.add("groups", "The groups must be an array.")
+		}
+		for i, id := range ids {
+			if s.coll("groups").find(stringOf(id)) == nil {
+				v.add(fmt.Sprintf("groups.%d", i), "The selected group does not exist.")
+			}
+		}
+	}
+}
+
+func (s *Server) newSubscriber(body record) record {
+	now := s.timestamp()
+	rec := record{
+		"email":           body["email"],
+		"status":          "active",
+		"source":          "api",
+		"sent":            0,
+		"opens_count":     0,
+		"clicks_count":    0,
+		"open_rate":       0,
+		"click_rate":      0,
+		"ip_address":      body["ip_address"],
+		"subscribed_at":   now,
+		"unsubscribed_at": nil,
+		"created_at":      now,
+		"fields":          s.emptyFields(),
+		"groups":          []interface{}{},
+		"opted_in_at":     body["opted_in_at"],
+		"optin_ip":        body["optin_ip"],
+	}
+	s.applySubscriber(rec, body)
+	return rec
+}
+
+// applySubscriber merges an upsert or update body into a subscriber.
+// Groups in the body are added to the subscriber's groups.
+func (s *Server) applySubscriber(rec, body record) {
+	for _, k := range []string{"email", "status", "ip_address", "subscribed_at", "opted_in_at", "optin_ip"} {
+		if val, ok := body[k]; ok && val != nil {
+			rec[k] = val
+		}
+	}
+	if status := stringOf(body["status"]); status == "unsubscribed" && rec["unsubscribed_at"] == nil {
+		rec["unsubscribed_at"] = s.timestamp()
+	}
+	if fields, ok := body["fields"].(map[string]interface{}); ok {
+		stored := rec["fields"].(map[string]interface{})
+		for k, val := range fields {
+			if _, known := stored[k]; known {
+				stored[k] = val
+			}
+		}
+	}
+	if groups, ok := body["groups"].([]interface{}); ok {
+		addGroups(rec, groups...)
+	}
+	rec["updated_at"] = s.timestamp()
+}
+
+func (s *Server) emptyFields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, f := range s.coll("fields").items {
+		fields[stringOf(f["key"])] = nil
+	}
+	return fields
+}
+
+func addGroups(rec record, ids ...interface{}) {
+	groups := rec["groups"].([]interface{})
+	for _, id := range ids {
+		if !slices.Contains(groups, interface{}(stringOf(id))) {
+			groups = append(groups, stringOf(id))
+		}
+	}
+	rec["groups"] = groups
+}
+
+func removeGroup(rec record, id string) {
+	rec["groups"] = slices.DeleteFunc(slices.Clone(rec["groups"].([]interface{})), func(g interface{}) bool {
+		return g == id
+	})
+}
+
+func inGroup(rec record, id string) bool {
+	return slices.Contains(rec["groups"].([]interface{}), interface{}(id))
+}
+
+// subscriberView replaces the stored group IDs with the groups themselves.
+func (s *Server) subscriberView(rec record) record {
+	out := maps.Clone(rec)
+	groups := []interface{}{}
+	for _, id := range rec["groups"].([]interface{}) {
+		if g := s.coll("groups").find(stringOf(id)); g != nil {
+			groups = append(groups, record{"id": g["id"], "name": g["name"], "created_at": g["created_at"]})
+		}
+	}
+	out["groups"] = groups
+	return out
+}
+
+func (s *Server) subscriberViews(items []record) []record {
+	out := make([]record, len(items))
+	for i, rec := range items {
+		out[i] = s.subscriberView(rec)
+	}
+	return out
+}
+
+// memberSubscribers returns the subscribers listed under key in s.members
+// that still exist, in order.
+func (s *Server) memberSubscribers(key string) []record {
+	var out []record
+	for _, id := range s.members[key] {
+		if rec := s.coll("subscribers").find(id); rec != nil {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
+
+// audienceStats sums the sending statistics of a set of subscribers, in the
+// shape used by groups and segments.
+func audienceStats(subscribers []record) record {
+	var sent, opens, clicks float64
+	for _, sub := range subscribers {
+		n, _ := number(sub["sent"])
+		o, _ := number(sub["opens_count"])
+		c, _ := number(sub["clicks_count"])
+		sent, opens, clicks = sent+n, opens+o, clicks+c
+	}
+	return record{
+		"sent_count":   int(sent),
+		"opens_count":  int(opens),
+		"open_rate":    rate(opens, sent),
+		"clicks_count": int(clicks),
+		"click_rate":   rate(clicks, sent),
+	}
+}
+
+// rate returns a ratio in the API's {"float", "string"} form.
+func rate(part, total float64) record {
+	r := 0.0
+	if total > 0 {
+		r = math.Round(part/total*10000) / 10000
+	}
+	return record{"float": r, "string": stringOf(math.Round(r*10000)/100) + "%"}
+}
+
+// --- groups ---
+
+func (s *Server) groupRoutes() {
+	res := resource{
+		validate: func(v *validation, body record, create bool) {
+			v.str(body, "name", true, 255)
+		},
+		build: func(body record) record {
+			return record{"name": body["name"]}
+		},
+		view: s.groupView,
+	}
+	s.handle("GET /groups", func(req *request) (int, interface{}) {
+		return s.list(req, "groups", res, "name")
+	})
+	s.handle("POST /groups", func(req *request) (int, interface{}) {
+		return s.create(req, "groups", res)
+	})
+	s.handle("PUT /groups/{id}", func(req *request) (int, interface{}) {
+		return s.update(req, "groups", req.PathValue("id"), res)
+	})
+	s.handle("DELETE /groups/{id}", func(req *request) (int, interface{}) {
+		id := req.PathValue("id")
+		for _, sub := range s.coll("subscribers").items {
+			removeGroup(sub, id)
+		}
+		return s.delete("groups", id)
+	})
+	s.handle("GET /groups/{id}/subscribers", func(req *request) (int, interface{}) {
+		id := req.PathValue("id")
+		if s.coll("groups").find(id) == nil {
+			return notFound()
+		}
+		members := slices.DeleteFunc(slices.Clone(s.coll("subscribers").items), func(sub record) bool {
+			return !inGroup(sub, id)
+		})
+		items, errResp := filterRecords(req, members, "status")
+		if errResp != nil {
+			return http.StatusUnprocessableEntity, errResp
+		}
+		return paginate(req, s.subscriberViews(items))
+	})
+}
+
+// groupView adds subscriber counts and statistics computed from the
+// group's members.
+func (s *Server) groupView(rec record) record {
+	var members []record
+	counts := map[string]int{}
+	for _, sub := range s.coll("subscribers").items {
+		if inGroup(sub, stringOf(rec["id"])) {
+			members = append(members, sub)
+			counts[stringOf(sub["status"])]++
+		}
+	}
+	out := audienceStats(members)
+	for k, v := range rec {
+		out[k] = v
+	}
+	out["active_count"] = counts["active"]
+	out["unsubscribed_count"] = counts["unsubscribed"]
+	out["unconfirmed_count"] = counts["unconfirmed"]
+	out["bounced_count"] = counts["bounced"]
+	out["junk_count"] = counts["junk"]
+	return out
+}
+
+// --- fields ---
+
+var fieldTypes = []string{"text", "number", "date"}
+
+func (s *Server) fieldRoutes() {
+	res := resource{
+		validate: func(v *validation, body record, create bool) {
+			v.str(body, "name", true, 255)
+			if create {
+				v.oneOf(body, "type", true, fieldTypes...)
+				for _, f := range s.coll("fields").items {
+					if strings.EqualFold(stringOf(f["name"]), stringOf(body["name"])) {
+						v.add("name", "The name has already been taken.")
+					}
+				}
+			}
+		},
+		build: func(body record) record {
+			key := fieldKey(stringOf(body["name"]))
+			for _, sub := range s.coll("subscribers").items {
+				sub["fields"].(map[string]interface{})[key] = nil
+			}
+			return record{"name": body["name"], "key": key, "type": body["type"]}
+		},
+	}
+	s.handle("GET /fields", func(req *request) (int, interface{}) {
+		return s.list(req, "fields", res, "keyword", "type")
+	})
+	s.handle("POST /fields", func(req *request) (int, interface{}) {
+		return s.create(req, "fields", res)
+	})
+	s.handle("PUT /fields/{id}", func(req *request) (int, interface{}) {
+		return s.update(req, "fields", req.PathValue("id"), res)
+	})
+	s.handle("DELETE /fields/{id}", func(req *request) (int, interface{}) {
+		if f := s.coll("fields").find(req.PathValue("id")); f != nil {
+			for _, sub := range s.coll("subscribers").items {
+				delete(sub["fields"].(map[string]interface{}), stringOf(f["key"]))
+			}
+		}
+		return s.delete("fields", req.PathValue("id"))
+	})
+}
+
+var nonKeyChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// fieldKey derives a field's merge tag key from its name.
+func fieldKey(name string) string {
+	return strings.Trim(nonKeyChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
+
+// --- segments ---
+
+func (s *Server) segmentRoutes() {
+	res := resource{
+		validate: func(v *validation, body record, create bool) {
+			v.str(body, "name", true, 255)
+		},
+		view: func(rec record) record {
+			members := s.memberSubscribers("segments/" + stringOf(rec["id"]))
+			stats := audienceStats(members)
+			rec["total"] = len(members)
+			rec["open_rate"] = stats["open_rate"]
+			rec["click_rate"] = stats["click_rate"]
+			return rec
+		},
+	}
+	s.handle("GET /segments", func(req *request) (int, interface{}) {
+		return s.list(req, "segments", res)
+	})
+	s.handle("PUT /segments/{id}", func(req *request) (int, interface{}) {
+		return s.update(req, "segments", req.PathValue("id"), res)
+	})
+	s.handle("DELETE /segments/{id}", func(req *request) (int, interface{}) {
+		return s.delete("segments", req.PathValue("id"))
+	})
+	s.handle("GET /segments/{id}/subscribers", func(req *request) (int, interface{}) {
+		id := req.PathValue("id")
+		if s.coll("segments").find(id) == nil {
+			return notFound()
+		}
+		items, errResp := filterRecords(req, s.memberSubscribers("segments/"+id), "status")
+		if errResp != nil {
+			return http.StatusUnprocessableEntity, errResp
+		}
+		return paginateAfter(req, s.subscriberViews(items))
+	})
+}
+
+// --- forms ---
+
+var formTypes = []string{"popup", "embedded", "promotion"}
+
+func (s *Server) formRoutes() {
+	res := resource{
+		validate: func(v *validation, body record, create bool) {
+			v.str(body, "name", true, 255)
+		},
+		view: func(rec record) record {
+			conversions := len(s.memberSubscribers("forms/" + stringOf(rec["id"])))
+			opens, _ := number(rec["opens_count"])
+			rec["conversions_count"] = conversions
+			// The SDK decodes conversions_rate.float as an integer.
+			r := rate(float64(conversions), opens)
+			rec["conversions_rate"] = record{"float": 0, "string": r["string"]}
+			return rec
+		},
+	}
+	// The API serves both lists by type and single forms by ID here.
+	s.handle("GET /forms/{type}", func(req *request) (int, interface{}) {
+		typ := req.PathValue("type")
+		if !slices.Contains(formTypes, typ) {
+			return s.get("forms", typ, res)
+		}
+		forms := slices.DeleteFunc(slices.Clone(s.coll("forms").items), func(f record) bool {
+			return f["type"] != typ
+		})
+		return listRecords(req, forms, res, "name")
+	})
+	s.handle("PUT /forms/{id}", func(req *request) (int, interface{}) {
+		return s.update(req, "forms", req.PathValue("id"), res)
+	})
+	s.handle("DELETE /forms/{id}", func(req *request) (int, interface{}) {
+		return s.delete("forms", req.PathValue("id"))
+	})
+	s.handle("GET /forms/{id}/subscribers", func(req *request) (int, interface{}) {
+		id := req.PathValue("id")
+		if s.coll("forms").find(id) == nil {
+			return notFound()
+		}
+		items, errResp := filterRecords(req, s.memberSubscribers("forms/"+id), "status")
+		if errResp != nil {
+			return http.StatusUnprocessableEntity, errResp
+		}
+		return paginate(req, s.subscriberViews(items))
+	})
+}
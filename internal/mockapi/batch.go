@@ -0,0 +1,78 @@
+package mockapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+)
+
+// maxBatchRequests is the most requests one batch call may contain.
+const maxBatchRequests = 50
+
+// --- batch ---
+
+func (s *Server) batchRoutes() {
+	s.handle("POST /batch", s.batch)
+}
+
+// batch runs each request against the mock in order, as the real API's
+// /batch endpoint does. The batch counts as one request for rate limiting.
+func (s *Server) batch(req *request) (int, interface{}) {
+	var in struct {
+		Requests []struct {
+			Method string          `json:"method"`
+			Path   string          `json:"path"`
+			Body   json.RawMessage `json:"body"`
+		} `json:"requests"`
+	}
+	_ = json.Unmarshal(req.raw, &in)
+
+	v := &validation{}
+	switch {
+	case len(in.Requests) == 0:
+		v.add("requests", "The requests field is required.")
+	case len(in.Requests) > maxBatchRequests:
+		v.add("requests", fmt.Sprintf("The requests field must not have more than %d items.", maxBatchRequests))
+	}
+	for i, r := range in.Requests {
+		if !slices.Contains([]string{"GET", "POST", "PUT", "DELETE"}, strings.ToUpper(r.Method)) {
+			v.add(fmt.Sprintf("requests.%d.method", i), "The selected method is invalid.")
+		}
+		if !strings.HasPrefix(strings.TrimPrefix(r.Path, "/"), "api/") {
+			v.add(fmt.Sprintf("requests.%d.path", i), "The path must start with api/.")
+		}
+	}
+	if v.failed() {
+		return v.response()
+	}
+
+	responses := make([]record, 0, len(in.Requests))
+	successful := 0
+	for _, r := range in.Requests {
+		sub, err := http.NewRequestWithContext(req.Context(), strings.ToUpper(r.Method), "/"+strings.TrimPrefix(r.Path, "/"), bytes.NewReader(r.Body))
+		if err != nil {
+			responses = append(responses, record{"code": http.StatusBadRequest, "body": message(err.Error())})
+			continue
+		}
+		sub.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, sub)
+
+		var body interface{}
+		_ = json.Unmarshal(rec.Body.Bytes(), &body)
+		if rec.Code < 400 {
+			successful++
+		}
+		responses = append(responses, record{"code": rec.Code, "body": body})
+	}
+	return http.StatusOK, record{
+		"total":      len(responses),
+		"successful": successful,
+		"failed":     len(responses) - successful,
+		"responses":  responses,
+	}
+}
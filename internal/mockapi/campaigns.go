@@ -0,0 +1,567 @@
+package mockapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// accountID is the ID of the mock account.
+const accountID = "100001"
+
+var (
+	campaignTypes    = []string{"regular", "ab", "resend", "rss"}
+	deliveryTypes    = []string{"instant", "scheduled", "timezone_based"}
+	campaignTypeName = map[string]string{"regular": "Regular", "ab": "A/B split", "resend": "Auto resend", "rss": "RSS"}
+)
+
+// --- campaigns ---
+
+func (s *Server) campaignRoutes() {
+	res := resource{
+		validate: s.validateCampaign,
+		build:    s.newCampaign,
+	}
+	listed := res
+	listed.view = listedCampaign
+	s.handle("GET /campaigns", func(req *request) (int, interface{}) {
+		return s.list(req, "campaigns", listed, "status", "type")
+	})
+	s.handle("GET /campaigns/languages", func(req *request) (int, interface{}) {
+		return http.StatusOK, data(s.coll("languages").items)
+	})
+	s.handle("GET /campaigns/{id}", func(req *request) (int, interface{}) {
+		return s.get("campaigns", req.PathValue("id"), res)
+	})
+	s.handle("POST /campaigns", func(req *request) (int, interface{}) {
+		return s.create(req, "campaigns", res)
+	})
+	s.handle("PUT /campaigns/{id}", func(req *request) (int, interface{}) {
+		rec := s.coll("campaigns").find(req.PathValue("id"))
+		if rec == nil {
+			return notFound()
+		}
+		if rec["status"] != "draft" {
+			return http.StatusUnprocessableEntity, message("Only draft campaigns can be updated.")
+		}
+		v := &validation{}
+		s.validateCampaign(v, req.body, false)
+		if v.failed() {
+			return v.response()
+		}
+		s.applyCampaign(rec, req.body)
+		return http.StatusOK, data(rec)
+	})
+	s.handle("DELETE /campaigns/{id}", func(req *request) (int, interface{}) {
+		return s.delete("campaigns", req.PathValue("id"))
+	})
+	s.handle("POST /campaigns/{id}/schedule", s.scheduleCampaign)
+	s.handle("POST /campaigns/{id}/cancel", func(req *request) (int, interface{}) {
+		rec := s.coll("campaigns").find(req.PathValue("id"))
+		if rec == nil {
+			return notFound()
+		}
+		if rec["status"] != "ready" {
+			return http.StatusUnprocessableEntity, message("Only scheduled campaigns can be cancelled.")
+		}
+		rec["status"] = "draft"
+		rec["scheduled_for"] = nil
+		rec["delivery_schedule"] = nil
+		rec["can_be_scheduled"] = true
+		rec["updated_at"] = s.timestamp()
+		return http.StatusOK, data(rec)
+	})
+	s.handle("POST /campaigns/{id}/reports/subscriber-activity", s.campaignActivity)
+}
+
+func (s *Server) validateCampaign(v *validation, body record, create bool) {
+	v.str(body, "name", create, 255)
+	v.oneOf(body, "type", create, campaignTypes...)
+	if v.check(body, "emails", create) {
+		var email map[string]interface{}
+		if emails, _ := body["emails"].([]interface{}); len(emails) > 0 {
+			email, _ = emails[0].(map[string]interface{})
+		}
+		if email == nil {
+			v.add("emails", "The emails must be an array of emails.")
+		} else {
+			// Validate the first email's fields under their full names.
+			prefixed := record{}
+			for k, val := range email {
+				prefixed["emails.0."+k] = val
+			}
+			v.str(prefixed, "emails.0.subject", create, 255)
+			v.str(prefixed, "emails.0.from_name", create, 255)
+			v.email(prefixed, "emails.0.from", create)
+		}
+	}
+	for _, kind := range []string{"groups", "segments"} {
+		ids, _ := body[kind].([]interface{})
+		for i, id := range ids {
+			if s.coll(kind).find(stringOf(id)) == nil {
+				v.add(fmt.Sprintf("%s.%d", kind, i), fmt.Sprintf("The selected %s.%d is invalid.", kind, i))
+			}
+		}
+	}
+	if id, ok := body["language_id"]; ok && id != nil && s.coll("languages").find(stringOf(id)) == nil {
+		v.add("language_id", "The selected language id is invalid.")
+	}
+}
+
+func (s *Server) newCampaign(body record) record {
+	now := s.timestamp()
+	id, emailID := s.newID(), s.newID()
+	typ := stringOf(body["type"])
+	rec := record{
+		"id":                             id,
+		"account_id":                     accountID,
+		"name":                           body["name"],
+		"type":                           typ,
+		"status":                         "draft",
+		"missing_data":                   []interface{}{},
+		"settings":                       record{"track_opens": true, "use_google_analytics": false, "ecommerce_tracking": false},
+		"filter":                         []interface{}{},
+		"filter_for_humans":              []interface{}{},
+		"delivery_schedule":              nil,
+		"language_id":                    "1",
+		"created_at":                     now,
+		"scheduled_for":                  nil,
+		"queued_at":                      nil,
+		"started_at":                     nil,
+		"finished_at":                    nil,
+		"stopped_at":                     nil,
+		"default_email_id":               emailID,
+		"emails":                         []interface{}{s.newEmail(emailID, id, now)},
+		"used_in_automations":            false,
+		"type_for_humans":                campaignTypeName[typ],
+		"stats":                          campaignStats(0, 0, 0, 0),
+		"is_stopped":                     false,
+		"has_winner":                     nil,
+		"winner_version_for_human":       nil,
+		"winner_sending_time_for_humans": nil,
+		"winner_selected_manually_at":    nil,
+		"uses_ecommerce":                 false,
+		"uses_survey":                    false,
+		"can_be_scheduled":               true,
+		"warnings":                       []interface{}{},
+		"initial_created_at":             nil,
+		"is_currently_sending_out":       false,
+	}
+	s.applyCampaign(rec, body)
+	return rec
+}
+
+// listedCampaign is a campaign as the list endpoint serves it: like the
+// real API, without the content of its emails.
+func listedCampaign(rec record) record {
+	emails, _ := rec["emails"].([]interface{})
+	listed := make([]interface{}, len(emails))
+	for i, e := range emails {
+		email := maps.Clone(e.(record))
+		delete(email, "content")
+		delete(email, "plain_text")
+		listed[i] = email
+	}
+	rec["emails"] = listed
+	return rec
+}
+
+func (s *Server) newEmail(id, campaignID, now string) record {
+	return record{
+		"id":             id,
+		"account_id":     accountID,
+		"emailable_id":   campaignID,
+		"emailable_type": "campaigns",
+		"type":           "html",
+		"from":           "",
+		"from_name":      "",
+		"name":           nil,
+		"subject":        "",
+		"content":        "",
+		"plain_text":     "",
+		"screenshot_url": nil,
+		"preview_url":    "https://preview.mailerlite.io/" + id,
+		"created_at":     now,
+		"updated_at":     now,
+		"is_designed":    true,
+		"language_id":    1,
+		"is_winner":      false,
+		"stats":          campaignStats(0, 0, 0, 0),
+		"send_after":     nil,
+		"track_opens":    true,
+	}
+}
+
+// applyCampaign merges a create or update body into a campaign. Empty email
+// fields keep their current values.
+func (s *Server) applyCampaign(rec, body record) {
+	for _, k := range []string{"name", "type"} {
+		if present(body, k) {
+			rec[k] = body[k]
+		}
+	}
+	rec["type_for_humans"] = campaignTypeName[stringOf(rec["type"])]
+	if present(body, "language_id") {
+		rec["language_id"] = stringOf(body["language_id"])
+	}
+	if emails, ok := body["emails"].([]interface{}); ok && len(emails) > 0 {
+		src, _ := emails[0].(map[string]interface{})
+		email := rec["emails"].([]interface{})[0].(record)
+		for _, k := range []string{"subject", "from", "from_name", "content", "plain_text"} {
+			if present(src, k) {
+				email[k] = src[k]
+			}
+		}
+		email["updated_at"] = s.timestamp()
+	}
+
+	groups, hasGroups := body["groups"].([]interface{})
+	segments, hasSegments := body["segments"].([]interface{})
+	if hasGroups || hasSegments {
+		var filter, humans []interface{}
+		for i, ids := range [][]interface{}{groups, segments} {
+			kind := []string{"groups", "segments"}[i]
+			if len(ids) == 0 {
+				continue
+			}
+			var names []interface{}
+			for _, id := range ids {
+				names = append(names, s.coll(kind).find(stringOf(id))["name"])
+			}
+			filter = append(filter, []interface{}{record{"operator": "in_any", "args": []interface{}{kind, ids}}})
+			humans = append(humans, []interface{}{fmt.Sprintf("In any of %s: %v", kind, names)})
+		}
+		rec["filter"] = orEmptyList(filter)
+		rec["filter_for_humans"] = orEmptyList(humans)
+	}
+	rec["updated_at"] = s.timestamp()
+}
+
+func orEmptyList(v []interface{}) []interface{} {
+	if v == nil {
+		return []interface{}{}
+	}
+	return v
+}
+
+// recipients returns the active subscribers a campaign is sent to: members
+// of its groups and segments, or everyone if it has neither.
+func (s *Server) recipients(campaign record) []record {
+	var groups, segments []string
+	for _, f := range campaign["filter"].([]interface{}) {
+		for _, cond := range f.([]interface{}) {
+			args := cond.(record)["args"].([]interface{})
+			for _, id := range args[1].([]interface{}) {
+				if args[0] == "groups" {
+					groups = append(groups, stringOf(id))
+				} else {
+					segments = append(segments, stringOf(id))
+				}
+			}
+		}
+	}
+	inSegment := map[string]bool{}
+	for _, id := range segments {
+		for _, sub := range s.memberSubscribers("segments/" + id) {
+			inSegment[stringOf(sub["id"])] = true
+		}
+	}
+	var out []record
+	for _, sub := range s.coll("subscribers").items {
+		if sub["status"] != "active" {
+			continue
+		}
+		matches := len(groups) == 0 && len(segments) == 0 || inSegment[stringOf(sub["id"])] ||
+			slices.ContainsFunc(groups, func(id string) bool { return inGroup(sub, id) })
+		if matches {
+			out = append(out, sub)
+		}
+	}
+	return out
+}
+
+func (s *Server) scheduleCampaign(req *request) (int, interface{}) {
+	rec := s.coll("campaigns").find(req.PathValue("id"))
+	if rec == nil {
+		return notFound()
+	}
+	if rec["status"] == "sent" || rec["status"] == "sending" {
+		return http.StatusUnprocessableEntity, message("The campaign has already been sent.")
+	}
+
+	v := &validation{}
+	v.oneOf(req.body, "delivery", true, deliveryTypes...)
+	var at time.Time
+	if delivery := stringOf(req.body["delivery"]); delivery == "scheduled" || delivery == "timezone_based" {
+		at = s.validateSchedule(v, req.body)
+	}
+	email := rec["emails"].([]interface{})[0].(record)
+	if email["subject"] == "" || email["from"] == "" {
+		v.add("emails", "The campaign email is missing a subject or sender.")
+	}
+	if v.failed() {
+		return v.response()
+	}
+
+	now := s.timestamp()
+	rec["delivery_schedule"] = req.body["delivery"]
+	rec["updated_at"] = now
+	if req.body["delivery"] != "instant" {
+		rec["status"] = "ready"
+		rec["scheduled_for"] = at.Format(timeLayout)
+		rec["can_be_scheduled"] = false
+		return http.StatusOK, data(rec)
+	}
+
+	recipients := s.recipients(rec)
+	path := "campaigns/" + stringOf(rec["id"]) + "/subscriber-activity"
+	for _, sub := range recipients {
+		s.insert(path, record{"subscriber_id": sub["id"], "opens_count": 0, "clicks_count": 0})
+		sent, _ := number(sub["sent"])
+		sub["sent"] = sent + 1
+	}
+	rec["status"] = "sent"
+	rec["queued_at"], rec["started_at"], rec["finished_at"] = now, now, now
+	rec["can_be_scheduled"] = false
+	rec["stats"] = campaignStats(len(recipients), 0, 0, 0)
+	email["stats"] = rec["stats"]
+	return http.StatusOK, data(rec)
+}
+
+// validateSchedule checks schedule.date, hours and minutes and returns the
+// scheduled time, which must be in the future.
+func (s *Server) validateSchedule(v *validation, body record) time.Time {
+	schedule, _ := body["schedule"].(map[string]interface{})
+	if schedule == nil {
+		v.add("schedule", "The schedule field is required when delivery is scheduled.")
+		return time.Time{}
+	}
+	prefixed := record{}
+	for k, val := range schedule {
+		prefixed["schedule."+k] = val
+	}
+	date, dateErr := time.Parse(time.DateOnly, stringOf(schedule["date"]))
+	if v.check(prefixed, "schedule.date", true) && dateErr != nil {
+		v.add("schedule.date", "The schedule.date does not match the format Y-m-d.")
+	}
+	hours, hErr := strconv.Atoi(stringOf(schedule["hours"]))
+	if v.check(prefixed, "schedule.hours", true) && (hErr != nil || hours < 0 || hours > 23) {
+		v.add("schedule.hours", "The schedule.hours must be between 0 and 23.")
+	}
+	minutes, mErr := strconv.Atoi(stringOf(schedule["minutes"]))
+	if v.check(prefixed, "schedule.minutes", true) && (mErr != nil || minutes < 0 || minutes > 59) {
+		v.add("schedule.minutes", "The schedule.minutes must be between 0 and 59.")
+	}
+	offset := 0
+	if present(schedule, "timezone_id") {
+		tz := s.coll("timezones").find(stringOf(schedule["timezone_id"]))
+		if tz == nil {
+			v.add("schedule.timezone_id", "The selected schedule.timezone id is invalid.")
+		} else {
+			n, _ := number(tz["offset"])
+			offset = int(n)
+		}
+	}
+	if v.failed() {
+		return time.Time{}
+	}
+	at := time.Date(date.Year(), date.Month(), date.Day(), hours, minutes, 0, 0, time.FixedZone("", offset))
+	if !at.After(s.now()) {
+		v.add("schedule.date", "The scheduled time must be in the future.")
+	}
+	return at
+}
+
+// campaignStats returns campaign statistics with the rates derived from the
+// counts.
+func campaignStats(sent, opens, clicks, unsubscribes int) record {
+	total := float64(sent)
+	return record{
+		"sent":                sent,
+		"opens_count":         opens,
+		"unique_opens_count":  opens,
+		"open_rate":           rate(float64(opens), total),
+		"clicks_count":        clicks,
+		"unique_clicks_count": clicks,
+		"click_rate":          rate(float64(clicks), total),
+		"unsubscribes_count":  unsubscribes,
+		"unsubscribe_rate":    rate(float64(unsubscribes), total),
+		"spam_count":          0,
+		"spam_rate":           rate(0, total),
+		"hard_bounces_count":  0,
+		"hard_bounce_rate":    rate(0, total),
+		"soft_bounces_count":  0,
+		"soft_bounce_rate":    rate(0, total),
+		"forwards_count":      0,
+		"click_to_open_rate":  rate(float64(clicks), float64(opens)),
+	}
+}
+
+// activityTypes are the campaign report filters and whether a subscriber's
+// activity matches them.
+var activityTypes = map[string]func(activity, sub record) bool{
+	"opened":       func(a, _ record) bool { n, _ := number(a["opens_count"]); return n > 0 },
+	"unopened":     func(a, _ record) bool { n, _ := number(a["opens_count"]); return n == 0 },
+	"clicked":      func(a, _ record) bool { n, _ := number(a["clicks_count"]); return n > 0 },
+	"unsubscribed": func(_, sub record) bool { return sub["status"] == "unsubscribed" },
+	"forwarded":    func(_, _ record) bool { return false },
+	"hardbounced":  func(_, sub record) bool { return sub["status"] == "bounced" },
+	"softbounced":  func(_, _ record) bool { return false },
+	"junk":         func(_, sub record) bool { return sub["status"] == "junk" },
+}
+
+// campaignActivity serves the per-subscriber report of a campaign. The SDK
+// sends its options, including filters, in the POST body.
+func (s *Server) campaignActivity(req *request) (int, interface{}) {
+	id := req.PathValue("id")
+	if s.coll("campaigns").find(id) == nil {
+		return notFound()
+	}
+
+	typ := req.URL.Query().Get("filter[type]")
+	if filters, ok := req.body["filters"].([]interface{}); ok {
+		for _, f := range filters {
+			if f, ok := f.(map[string]interface{}); ok && f["name"] == "type" {
+				typ = stringOf(f["value"])
+			}
+		}
+	}
+	if filter, ok := req.body["filter"].(map[string]interface{}); ok && present(filter, "type") {
+		typ = stringOf(filter["type"])
+	}
+	match, ok := activityTypes[typ]
+	if typ != "" && !ok {
+		v := &validation{}
+		v.add("filter.type", "The selected filter.type is invalid.")
+		return v.response()
+	}
+
+	var items []record
+	for _, a := range s.coll("campaigns/" + id + "/subscriber-activity").items {
+		sub := s.coll("subscribers").find(stringOf(a["subscriber_id"]))
+		if sub == nil || (match != nil && !match(a, sub)) {
+			continue
+		}
+		items = append(items, record{
+			"id":           a["id"],
+			"opens_count":  a["opens_count"],
+			"clicks_count": a["clicks_count"],
+			"subscriber":   s.subscriberView(sub),
+		})
+	}
+	if errResp := sortRecords(req, items); errResp != nil {
+		return http.StatusUnprocessableEntity, errResp
+	}
+	return paginate(req, items)
+}
+
+// --- automations ---
+
+func (s *Server) automationRoutes() {
+	s.handle("GET /automations", func(req *request) (int, interface{}) {
+		return s.list(req, "automations", resource{}, "enabled", "name", "group")
+	})
+	s.handle("GET /automations/{id}", func(req *request) (int, interface{}) {
+		return s.get("automations", req.PathValue("id"), resource{})
+	})
+	s.handle("GET /automations/{id}/activity", func(req *request) (int, interface{}) {
+		id := req.PathValue("id")
+		if s.coll("automations").find(id) == nil {
+			return notFound()
+		}
+		return s.list(req, "automations/"+id+"/activity", resource{}, "status")
+	})
+}
+
+// --- webhooks ---
+
+var webhookEvents = []string{
+	"subscriber.created", "subscriber.updated", "subscriber.unsubscribed",
+	"subscriber.added_to_group", "subscriber.removed_from_group", "subscriber.bounced",
+	"subscriber.automation_triggered", "subscriber.automation_completed",
+	"subscriber.spam_reported", "subscriber.deleted", "campaign.sent",
+	"campaign.open", "campaign.click",
+}
+
+func (s *Server) webhookRoutes() {
+	res := resource{
+		validate: func(v *validation, body record, create bool) {
+			v.str(body, "name", false, 255)
+			v.url(body, "url", create)
+			if v.check(body, "events", create) {
+				events, ok := body["events"].([]interface{})
+				if !ok {
+					v.add("events", "The events must be an array.")
+				}
+				for i, e := range events {
+					if !slices.Contains(webhookEvents, stringOf(e)) {
+						v.add(fmt.Sprintf("events.%d", i), fmt.Sprintf("The selected events.%d is invalid.", i))
+					}
+				}
+			}
+			if e, ok := body["enabled"]; ok {
+				if _, ok := e.(bool); !ok {
+					v.add("enabled", "The enabled field must be true or false.")
+				}
+			}
+		},
+		build: func(body record) record {
+			return record{
+				"name":    body["name"],
+				"url":     body["url"],
+				"events":  body["events"],
+				"enabled": true,
+				"secret":  secret(stringOf(body["url"]) + s.timestamp()),
+			}
+		},
+	}
+	s.handle("GET /webhooks", func(req *request) (int, interface{}) {
+		return s.list(req, "webhooks", res)
+	})
+	s.handle("GET /webhooks/{id}", func(req *request) (int, interface{}) {
+		return s.get("webhooks", req.PathValue("id"), res)
+	})
+	s.handle("POST /webhooks", func(req *request) (int, interface{}) {
+		return s.create(req, "webhooks", res)
+	})
+	s.handle("PUT /webhooks/{id}", func(req *request) (int, interface{}) {
+		// The SDK sends enabled as a string.
+		switch req.body["enabled"] {
+		case "true":
+			req.body["enabled"] = true
+		case "false":
+			req.body["enabled"] = false
+		}
+		return s.update(req, "webhooks", req.PathValue("id"), res)
+	})
+	s.handle("DELETE /webhooks/{id}", func(req *request) (int, interface{}) {
+		return s.delete("webhooks", req.PathValue("id"))
+	})
+}
+
+// secret returns a webhook signing secret derived from seed.
+func secret(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:16])
+}
+
+// --- timezones ---
+
+func (s *Server) timezoneRoutes() {
+	s.handle("GET /timezones", func(req *request) (int, interface{}) {
+		return http.StatusOK, data(s.coll("timezones").items)
+	})
+}
+
+// --- accounts ---
+
+func (s *Server) accountRoutes() {
+	s.handle("GET /accounts", func(req *request) (int, interface{}) {
+		return http.StatusOK, data([]record{{"id": accountID, "name": "Demo account", "status": "active"}})
+	})
+}
@@ -0,0 +1,340 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+var orderStatuses = []string{"pending", "paid", "complete", "canceled", "refunded"}
+
+func (s *Server) ecommerceRoutes() {
+	shops := resource{
+		validate: func(v *validation, body record, create bool) {
+			v.str(body, "name", create, 255)
+			v.url(body, "url", create)
+		},
+		build: func(body record) record {
+			return record{"name": body["name"], "url": body["url"]}
+		},
+	}
+	s.handle("GET /ecommerce/shops", func(req *request) (int, interface{}) {
+		return s.list(req, "ecommerce/shops", shops, "name")
+	})
+	s.handle("POST /ecommerce/shops", func(req *request) (int, interface{}) {
+		return s.create(req, "ecommerce/shops", shops)
+	})
+	s.handle("GET /ecommerce/shops/{shop}", func(req *request) (int, interface{}) {
+		return s.get("ecommerce/shops", req.PathValue("shop"), shops)
+	})
+	s.handle("PUT /ecommerce/shops/{shop}", func(req *request) (int, interface{}) {
+		return s.update(req, "ecommerce/shops", req.PathValue("shop"), shops)
+	})
+	s.handle("DELETE /ecommerce/shops/{shop}", func(req *request) (int, interface{}) {
+		prefix := "ecommerce/shops/" + req.PathValue("shop") + "/"
+		for path := range s.collections {
+			if strings.HasPrefix(path, prefix) {
+				delete(s.collections, path)
+			}
+		}
+		return s.delete("ecommerce/shops", req.PathValue("shop"))
+	})
+
+	// Products, categories, customers, orders and carts share their routes.
+	s.handle("GET /ecommerce/shops/{shop}/{resource}", s.shopHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.list(req, path, res, "name", "email", "status", "customer_id")
+	}))
+	s.handle("POST /ecommerce/shops/{shop}/{resource}", s.shopHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.create(req, path, res)
+	}))
+	s.handle("GET /ecommerce/shops/{shop}/{resource}/{id}", s.shopHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.get(path, req.PathValue("id"), res)
+	}))
+	s.handle("PUT /ecommerce/shops/{shop}/{resource}/{id}", s.shopHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.update(req, path, req.PathValue("id"), res)
+	}))
+	s.handle("DELETE /ecommerce/shops/{shop}/{resource}/{id}", s.shopHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.delete(path, req.PathValue("id"))
+	}))
+	s.handle("POST /ecommerce/shops/{shop}/{resource}/import", s.shopHandler(s.importRecords))
+
+	s.handle("GET /ecommerce/shops/{shop}/carts/{cart}/items", s.cartItemHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.list(req, path, res)
+	}))
+	s.handle("POST /ecommerce/shops/{shop}/carts/{cart}/items", s.cartItemHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.create(req, path, res)
+	}))
+	s.handle("GET /ecommerce/shops/{shop}/carts/{cart}/items/{id}", s.cartItemHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.get(path, req.PathValue("id"), res)
+	}))
+	s.handle("PUT /ecommerce/shops/{shop}/carts/{cart}/items/{id}", s.cartItemHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.update(req, path, req.PathValue("id"), res)
+	}))
+	s.handle("DELETE /ecommerce/shops/{shop}/carts/{cart}/items/{id}", s.cartItemHandler(func(req *request, path string, res resource) (int, interface{}) {
+		return s.delete(path, req.PathValue("id"))
+	}))
+
+	s.handle("GET /ecommerce/shops/{shop}/categories/{category}/products", s.categoryHandler(func(req *request, shop, key string) (int, interface{}) {
+		var products []record
+		for _, id := range s.members[key] {
+			if p := s.coll(shop + "/products").find(id); p != nil {
+				products = append(products, p)
+			}
+		}
+		return paginate(req, products)
+	}))
+	s.handle("POST /ecommerce/shops/{shop}/categories/{category}/products", s.categoryHandler(func(req *request, shop, key string) (int, interface{}) {
+		v := &validation{}
+		v.exists(req.body, "product_id", true, s.coll(shop+"/products"))
+		if v.failed() {
+			return v.response()
+		}
+		id := stringOf(req.body["product_id"])
+		if !slices.Contains(s.members[key], id) {
+			s.members[key] = append(s.members[key], id)
+		}
+		return http.StatusNoContent, nil
+	}))
+	s.handle("DELETE /ecommerce/shops/{shop}/categories/{category}/products/{product}", s.categoryHandler(func(req *request, shop, key string) (int, interface{}) {
+		id := req.PathValue("product")
+		if !slices.Contains(s.members[key], id) {
+			return notFound()
+		}
+		s.members[key] = slices.DeleteFunc(s.members[key], func(m string) bool { return m == id })
+		return http.StatusNoContent, nil
+	}))
+}
+
+// shopHandler resolves the shop and resource of a shop sub-resource route.
+func (s *Server) shopHandler(h func(req *request, path string, res resource) (int, interface{})) handlerFunc {
+	return func(req *request) (int, interface{}) {
+		shop := "ecommerce/shops/" + req.PathValue("shop")
+		if s.coll("ecommerce/shops").find(req.PathValue("shop")) == nil {
+			return notFound()
+		}
+		name := req.PathValue("resource")
+		res, ok := s.shopResource(shop, name)
+		if !ok {
+			return notFound()
+		}
+		return h(req, shop+"/"+name, res)
+	}
+}
+
+func (s *Server) cartItemHandler(h func(req *request, path string, res resource) (int, interface{})) handlerFunc {
+	return func(req *request) (int, interface{}) {
+		shop := "ecommerce/shops/" + req.PathValue("shop")
+		if s.coll("ecommerce/shops").find(req.PathValue("shop")) == nil || s.coll(shop+"/carts").find(req.PathValue("cart")) == nil {
+			return notFound()
+		}
+		return h(req, shop+"/carts/"+req.PathValue("cart")+"/items", s.cartItemResource(shop))
+	}
+}
+
+func (s *Server) categoryHandler(h func(req *request, shop, key string) (int, interface{})) handlerFunc {
+	return func(req *request) (int, interface{}) {
+		shop := "ecommerce/shops/" + req.PathValue("shop")
+		category := req.PathValue("category")
+		if s.coll("ecommerce/shops").find(req.PathValue("shop")) == nil || s.coll(shop+"/categories").find(category) == nil {
+			return notFound()
+		}
+		return h(req, shop, shop+"/categories/"+category+"/products")
+	}
+}
+
+// shopResource returns the resource definition for a collection of the shop
+// at path shop.
+func (s *Server) shopResource(shop, name string) (resource, bool) {
+	switch name {
+	case "products":
+		return resource{
+			validate: func(v *validation, body record, create bool) {
+				v.str(body, "name", create, 255)
+				v.number(body, "price", create, 0)
+				v.url(body, "url", false)
+				v.url(body, "image_url", false)
+				v.str(body, "description", false, 65535)
+				v.number(body, "quantity", false, 0)
+			},
+			build: func(body record) record {
+				rec := record{"name": body["name"], "price": body["price"], "url": "", "image_url": "", "description": "", "quantity": 0}
+				copyPresent(rec, body, "url", "image_url", "description", "quantity")
+				return rec
+			},
+		}, true
+	case "categories":
+		return resource{
+			validate: func(v *validation, body record, create bool) {
+				v.str(body, "name", create, 255)
+			},
+			build: func(body record) record {
+				return record{"name": body["name"]}
+			},
+		}, true
+	case "customers":
+		return resource{
+			validate: func(v *validation, body record, create bool) {
+				v.email(body, "email", create)
+				v.str(body, "first_name", false, 255)
+				v.str(body, "last_name", false, 255)
+				if create {
+					for _, c := range s.coll(shop + "/customers").items {
+						if strings.EqualFold(stringOf(c["email"]), stringOf(body["email"])) {
+							v.add("email", "The email has already been taken.")
+						}
+					}
+				}
+			},
+			build: func(body record) record {
+				rec := record{"email": body["email"], "first_name": "", "last_name": ""}
+				copyPresent(rec, body, "first_name", "last_name")
+				return rec
+			},
+		}, true
+	case "orders":
+		return resource{
+			validate: func(v *validation, body record, create bool) {
+				v.exists(body, "customer_id", create, s.coll(shop+"/customers"))
+				v.oneOf(body, "status", false, orderStatuses...)
+				v.number(body, "total", false, 0)
+				v.str(body, "currency", false, 3)
+				s.validateOrderItems(v, shop, body)
+			},
+			build: func(body record) record {
+				items, _ := body["items"].([]interface{})
+				rec := record{
+					"customer_id": body["customer_id"],
+					"status":      "pending",
+					"currency":    "EUR",
+					"items":       orEmptyList(items),
+					"total":       itemsTotal(items),
+				}
+				copyPresent(rec, body, "status", "currency", "total")
+				return rec
+			},
+		}, true
+	case "carts":
+		return resource{
+			validate: func(v *validation, body record, create bool) {
+				v.exists(body, "customer_id", false, s.coll(shop+"/customers"))
+				v.str(body, "currency", false, 3)
+			},
+			build: func(body record) record {
+				rec := record{"customer_id": "", "currency": "EUR", "total": 0}
+				copyPresent(rec, body, "customer_id", "currency")
+				return rec
+			},
+			view: func(rec record) record {
+				var items []interface{}
+				for _, item := range s.coll(shop + "/carts/" + stringOf(rec["id"]) + "/items").items {
+					items = append(items, item)
+				}
+				rec["total"] = itemsTotal(items)
+				return rec
+			},
+		}, true
+	}
+	return resource{}, false
+}
+
+func (s *Server) cartItemResource(shop string) resource {
+	products := s.coll(shop + "/products")
+	return resource{
+		validate: func(v *validation, body record, create bool) {
+			v.exists(body, "product_id", create, products)
+			v.number(body, "quantity", create, 1)
+			v.number(body, "price", false, 0)
+		},
+		build: func(body record) record {
+			rec := record{"product_id": body["product_id"], "quantity": body["quantity"], "price": products.find(stringOf(body["product_id"]))["price"]}
+			copyPresent(rec, body, "price")
+			return rec
+		},
+	}
+}
+
+func (s *Server) validateOrderItems(v *validation, shop string, body record) {
+	if _, ok := body["items"]; !ok {
+		return
+	}
+	items, ok := body["items"].([]interface{})
+	if !ok {
+		v.add("items", "The items must be an array.")
+		return
+	}
+	for i, item := range items {
+		prefixed := record{}
+		if m, ok := item.(map[string]interface{}); ok {
+			for k, val := range m {
+				prefixed[fmt.Sprintf("items.%d.%s", i, k)] = val
+			}
+		}
+		v.exists(prefixed, fmt.Sprintf("items.%d.product_id", i), true, s.coll(shop+"/products"))
+		v.number(prefixed, fmt.Sprintf("items.%d.quantity", i), true, 1)
+		v.number(prefixed, fmt.Sprintf("items.%d.price", i), true, 0)
+	}
+}
+
+// importRecords creates records in bulk. The body is an array of records, or
+// an object with the array under the resource's name. Nothing is imported
+// if any record is invalid.
+func (s *Server) importRecords(req *request, path string, res resource) (int, interface{}) {
+	name := req.PathValue("resource")
+	var items []interface{}
+	if err := json.Unmarshal(req.raw, &items); err != nil {
+		items, _ = req.body[name].([]interface{})
+	}
+	if len(items) == 0 {
+		v := &validation{}
+		v.add(name, fmt.Sprintf("The %s field is required.", name))
+		return v.response()
+	}
+
+	v := &validation{}
+	for i, item := range items {
+		body, _ := item.(map[string]interface{})
+		if body == nil {
+			v.add(fmt.Sprint(i), "Each item must be an object.")
+			continue
+		}
+		itemErrs := &validation{}
+		res.validate(itemErrs, body, true)
+		for _, field := range itemErrs.fields {
+			for _, msg := range itemErrs.errors[field] {
+				v.add(fmt.Sprintf("%d.%s", i, field), msg)
+			}
+		}
+	}
+	if v.failed() {
+		return v.response()
+	}
+	for _, item := range items {
+		s.insert(path, res.build(item.(map[string]interface{})))
+	}
+	return http.StatusOK, map[string]interface{}{
+		"message": fmt.Sprintf("%d %s imported.", len(items), name),
+		"data":    map[string]interface{}{"imported": len(items)},
+	}
+}
+
+// itemsTotal sums quantity * price over order or cart items.
+func itemsTotal(items []interface{}) float64 {
+	var total float64
+	for _, item := range items {
+		m, _ := item.(map[string]interface{})
+		q, _ := number(m["quantity"])
+		p, _ := number(m["price"])
+		total += q * p
+	}
+	return total
+}
+
+func copyPresent(dst, src record, keys ...string) {
+	for _, k := range keys {
+		if present(src, k) {
+			dst[k] = src[k]
+		}
+	}
+}
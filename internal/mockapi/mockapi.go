@@ -0,0 +1,218 @@
+// Package mockapi is an in-memory imitation of the MailerLite API for
+// offline development, demos and end-to-end tests of the CLI.
+//
+// The server is seeded with a realistic account (subscribers, groups,
+// campaigns, automations, forms, segments, webhooks and an e-commerce shop),
+// paginates like the real API, answers invalid input with 422 validation
+// errors and can enforce a rate limit that produces 429 responses. All
+// routes live under /api, so a client's base URL is the server URL + "/api".
+package mockapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimit is the real API's limit of requests per minute.
+const DefaultRateLimit = 120
+
+// Options configures a mock server.
+type Options struct {
+	// Token, if set, is the only API token accepted. Otherwise any
+	// non-empty bearer token is.
+	Token string
+	// RateLimit is the number of requests allowed per RateWindow before the
+	// server answers 429. Zero disables rate limiting.
+	RateLimit int
+	// RateWindow is the period RateLimit applies to. Zero means a minute,
+	// as in the real API.
+	RateWindow time.Duration
+	// Subscribers is the number of subscribers to seed. Zero seeds 150.
+	Subscribers int
+}
+
+// Server is an http.Handler serving the mock API. It is safe for concurrent
+// use; requests are handled one at a time.
+type Server struct {
+	opts    Options
+	mux     *http.ServeMux
+	mu      sync.Mutex
+	now     func() time.Time
+	limiter *limiter
+
+	// collections holds records by collection path, e.g. "groups" or
+	// "ecommerce/shops/1/products".
+	collections map[string]*collection
+	// members holds ordered record IDs for relationships that are not a
+	// field of either side, e.g. "segments/42" -> subscriber IDs.
+	members map[string][]string
+	nextID  int
+}
+
+// New returns a server seeded with sample data.
+func New(opts Options) *Server {
+	if opts.Subscribers <= 0 {
+		opts.Subscribers = 150
+	}
+	s := &Server{
+		opts:        opts,
+		mux:         http.NewServeMux(),
+		now:         time.Now,
+		collections: map[string]*collection{},
+		members:     map[string][]string{},
+		nextID:      1000,
+	}
+	if opts.RateLimit > 0 {
+		window := opts.RateWindow
+		if window <= 0 {
+			window = time.Minute
+		}
+		s.limiter = &limiter{limit: opts.RateLimit, window: window}
+	}
+	s.routes()
+	s.seed()
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.limiter != nil {
+		remaining, retryAfter := s.limiter.take(s.now())
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.limiter.limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		if retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second)/time.Second)))
+			writeJSON(w, http.StatusTooManyRequests, message("Too Many Attempts."))
+			return
+		}
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" || (s.opts.Token != "" && token != s.opts.Token) {
+		writeJSON(w, http.StatusUnauthorized, message("Unauthenticated."))
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// --- routing ---
+
+// request is an incoming API request with its decoded JSON body.
+type request struct {
+	*http.Request
+	raw  []byte
+	body record
+}
+
+// handlerFunc handles a request and returns the status code and the value
+// to encode as the response body. A nil value writes no body.
+type handlerFunc func(req *request) (int, interface{})
+
+// handle registers h for a pattern such as "GET /groups/{id}", relative to
+// the /api prefix.
+func (s *Server) handle(pattern string, h handlerFunc) {
+	method, path, _ := strings.Cut(pattern, " ")
+	s.mux.HandleFunc(method+" /api"+path, func(w http.ResponseWriter, r *http.Request) {
+		req := &request{Request: r, body: record{}}
+		if r.Body != nil {
+			dec := json.NewDecoder(r.Body)
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err == nil {
+				req.raw = raw
+				// Bodies that are not objects (the SDK sends "null" with
+				// DELETE) leave body empty.
+				_ = json.Unmarshal(raw, &req.body)
+				if req.body == nil {
+					req.body = record{}
+				}
+			} else if !errors.Is(err, io.EOF) {
+				writeJSON(w, http.StatusBadRequest, message("The request body is not valid JSON."))
+				return
+			}
+		}
+		status, v := h(req)
+		writeJSON(w, status, v)
+	})
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, message("The route "+strings.TrimPrefix(r.URL.Path, "/")+" could not be found."))
+	})
+
+	s.subscriberRoutes()
+	s.groupRoutes()
+	s.fieldRoutes()
+	s.segmentRoutes()
+	s.formRoutes()
+	s.campaignRoutes()
+	s.automationRoutes()
+	s.webhookRoutes()
+	s.timezoneRoutes()
+	s.accountRoutes()
+	s.ecommerceRoutes()
+	s.batchRoutes()
+}
+
+// --- responses ---
+
+// apiError is an error response in the API's format.
+type apiError struct {
+	Message string              `json:"message"`
+	Errors  map[string][]string `json:"errors,omitempty"`
+}
+
+func message(msg string) *apiError {
+	return &apiError{Message: msg}
+}
+
+func notFound() (int, interface{}) {
+	return http.StatusNotFound, message("Resource not found.")
+}
+
+func data(v interface{}) map[string]interface{} {
+	return map[string]interface{}{"data": v}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// --- rate limiting ---
+
+// limiter allows limit requests per fixed window.
+type limiter struct {
+	limit  int
+	window time.Duration
+	start  time.Time
+	count  int
+}
+
+// take counts a request and returns the requests remaining in the window,
+// or how long to wait if the limit has been reached.
+func (l *limiter) take(now time.Time) (int, time.Duration) {
+	if now.Sub(l.start) >= l.window {
+		l.start = now
+		l.count = 0
+	}
+	if l.count >= l.limit {
+		return 0, max(l.start.Add(l.window).Sub(now), time.Second)
+	}
+	l.count++
+	return l.limit - l.count, 0
+}
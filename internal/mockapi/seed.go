@@ -0,0 +1,473 @@
+package mockapi
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// seed fills the server with a sample account. The data is the same on
+// every run; only timestamps follow the current time.
+func (s *Server) seed() {
+	rng := rand.New(rand.NewPCG(1, 2))
+	now := s.now().UTC().Truncate(time.Hour)
+	ago := func(d time.Duration) string { return now.Add(-d).Format(timeLayout) }
+	day := 24 * time.Hour
+
+	s.seedReference()
+
+	for _, f := range [][2]string{
+		{"Name", "text"}, {"Last name", "text"}, {"Company", "text"}, {"Country", "text"},
+		{"City", "text"}, {"Phone", "text"}, {"State", "text"}, {"Z i p", "text"},
+	} {
+		s.insert("fields", record{"name": f[0], "key": fieldKey(f[0]), "type": f[1], "created_at": ago(400 * day)})
+	}
+
+	var groups []string
+	for i, name := range []string{"Newsletter", "Customers", "Beta testers", "Webinar attendees", "VIP"} {
+		g := s.insert("groups", record{"name": name, "created_at": ago(time.Duration(365-i*60) * day)})
+		groups = append(groups, stringOf(g["id"]))
+	}
+	// Share of subscribers in each group.
+	groupShare := []float64{0.7, 0.35, 0.1, 0.2, 0.05}
+
+	firstNames := []string{"Ona", "Jonas", "Emma", "Liam", "Sofia", "Lukas", "Mia", "Noah", "Ava", "Matas", "Lea", "Ben", "Chloe", "Hugo", "Ines"}
+	lastNames := []string{"Kazlauskas", "Smith", "Muller", "Garcia", "Martin", "Petrauskas", "Rossi", "Jones", "Schmidt", "Dubois"}
+	places := [][2]string{
+		{"Lithuania", "Vilnius"}, {"Lithuania", "Kaunas"}, {"Germany", "Berlin"}, {"United States", "Austin"},
+		{"France", "Lyon"}, {"Spain", "Madrid"}, {"United Kingdom", "Leeds"}, {"Italy", "Turin"},
+	}
+	sources := []string{"api", "import", "webform", "manual"}
+	for i := 0; i < s.opts.Subscribers; i++ {
+		first, last := firstNames[i%len(firstNames)], lastNames[(i/len(firstNames))%len(lastNames)]
+		place := places[rng.IntN(len(places))]
+		status := "active"
+		switch r := rng.Float64(); {
+		case r > 0.98:
+			status = "junk"
+		case r > 0.95:
+			status = "bounced"
+		case r > 0.9:
+			status = "unconfirmed"
+		case r > 0.8:
+			status = "unsubscribed"
+		}
+		sent := rng.IntN(25)
+		opens := rng.IntN(sent + 1)
+		clicks := rng.IntN(opens + 1)
+		subscribed := now.Add(-time.Duration(rng.IntN(360)+1) * day)
+
+		var unsubscribedAt interface{}
+		if status == "unsubscribed" {
+			unsubscribedAt = subscribed.Add(time.Duration(rng.IntN(30)+1) * day).Format(timeLayout)
+		}
+		var memberOf []interface{}
+		for g, share := range groupShare {
+			if rng.Float64() < share {
+				memberOf = append(memberOf, groups[g])
+			}
+		}
+		s.insert("subscribers", record{
+			"email":           fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(first), strings.ToLower(last), i+1),
+			"status":          status,
+			"source":          sources[rng.IntN(len(sources))],
+			"sent":            sent,
+			"opens_count":     opens,
+			"clicks_count":    clicks,
+			"open_rate":       percent(opens, sent),
+			"click_rate":      percent(clicks, sent),
+			"ip_address":      fmt.Sprintf("203.0.113.%d", rng.IntN(254)+1),
+			"subscribed_at":   subscribed.Format(timeLayout),
+			"unsubscribed_at": unsubscribedAt,
+			"created_at":      subscribed.Format(timeLayout),
+			"opted_in_at":     subscribed.Format(timeLayout),
+			"optin_ip":        nil,
+			"fields": map[string]interface{}{
+				"name": first, "last_name": last, "company": nil, "country": place[0],
+				"city": place[1], "phone": nil, "state": nil, "z_i_p": nil,
+			},
+			"groups": orEmptyList(memberOf),
+		})
+	}
+	subscribers := s.coll("subscribers").items
+
+	for _, seg := range []struct {
+		name  string
+		match func(sub record) bool
+	}{
+		{"Engaged readers", func(sub record) bool { n, _ := number(sub["opens_count"]); return n >= 5 }},
+		{"Never opened", func(sub record) bool {
+			n, _ := number(sub["sent"])
+			o, _ := number(sub["opens_count"])
+			return n > 0 && o == 0
+		}},
+		{"Lithuania", func(sub record) bool {
+			return sub["fields"].(map[string]interface{})["country"] == "Lithuania"
+		}},
+	} {
+		rec := s.insert("segments", record{"name": seg.name, "created_at": ago(200 * day)})
+		key := "segments/" + stringOf(rec["id"])
+		for _, sub := range subscribers {
+			if sub["status"] == "active" && seg.match(sub) {
+				s.members[key] = append(s.members[key], stringOf(sub["id"]))
+			}
+		}
+	}
+
+	var forms []string
+	for _, f := range [][2]string{
+		{"Exit intent popup", "popup"}, {"Spring sale popup", "popup"},
+		{"Footer signup", "embedded"}, {"Blog sidebar", "embedded"}, {"Webinar landing page", "promotion"},
+	} {
+		rec := s.insert("forms", record{
+			"type":                 f[1],
+			"slug":                 fieldKey(f[0]),
+			"name":                 f[0],
+			"created_at":           ago(300 * day),
+			"opens_count":          500 + rng.IntN(4500),
+			"settings":             map[string]interface{}{},
+			"last_registration_at": ago(time.Duration(rng.IntN(72)) * time.Hour),
+			"active":               true,
+			"is_broken":            false,
+			"has_content":          true,
+			"can":                  record{"update": true},
+			"used_in_automations":  false,
+			"warnings":             []interface{}{},
+			"double_optin":         nil,
+			"screenshot_url":       nil,
+		})
+		forms = append(forms, stringOf(rec["id"]))
+	}
+	webform := 0
+	for _, sub := range subscribers {
+		if sub["source"] == "webform" {
+			key := "forms/" + forms[webform%len(forms)]
+			s.members[key] = append(s.members[key], stringOf(sub["id"]))
+			webform++
+		}
+	}
+
+	s.seedCampaigns(rng, now, groups)
+	s.seedAutomations(rng, now, groups)
+
+	s.insert("webhooks", record{
+		"name": "CRM sync", "url": "https://crm.example.com/hooks/mailerlite",
+		"events":  []interface{}{"subscriber.created", "subscriber.updated", "subscriber.unsubscribed"},
+		"enabled": true, "secret": secret("crm"), "created_at": ago(90 * day),
+	})
+	s.insert("webhooks", record{
+		"name": "Slack alerts", "url": "https://hooks.slack.example.com/services/T000/B000",
+		"events":  []interface{}{"campaign.sent"},
+		"enabled": false, "secret": secret("slack"), "created_at": ago(30 * day),
+	})
+
+	s.seedShop(rng, now)
+}
+
+// seedReference adds the fixed lists of campaign languages and timezones.
+func (s *Server) seedReference() {
+	for _, l := range [][4]string{
+		{"en", "eng", "English", "ltr"}, {"lt", "lit", "Lithuanian", "ltr"}, {"de", "deu", "German", "ltr"},
+		{"fr", "fra", "French", "ltr"}, {"es", "spa", "Spanish", "ltr"}, {"ar", "ara", "Arabic", "rtl"},
+	} {
+		id := stringOf(len(s.coll("languages").items) + 1)
+		c := s.coll("languages")
+		c.items = append(c.items, record{"id": id, "shortcode": l[0], "iso639": l[1], "name": l[2], "direction": l[3]})
+	}
+
+	for _, tz := range []struct {
+		name, city string
+		offset     int
+	}{
+		{"Pacific/Honolulu", "Honolulu", -10 * 3600},
+		{"America/Los_Angeles", "Pacific Time (US & Canada)", -8 * 3600},
+		{"America/New_York", "Eastern Time (US & Canada)", -5 * 3600},
+		{"UTC", "UTC", 0},
+		{"Europe/London", "London", 0},
+		{"Europe/Berlin", "Berlin", 3600},
+		{"Europe/Vilnius", "Vilnius", 2 * 3600},
+		{"Asia/Tokyo", "Tokyo", 9 * 3600},
+		{"Australia/Sydney", "Sydney", 10 * 3600},
+	} {
+		sign, offset := "+", tz.offset
+		if offset < 0 {
+			sign, offset = "-", -offset
+		}
+		offsetName := fmt.Sprintf("%s%02d:%02d", sign, offset/3600, offset%3600/60)
+		c := s.coll("timezones")
+		c.items = append(c.items, record{
+			"id":              s.newID(),
+			"name":            tz.name,
+			"name_for_humans": fmt.Sprintf("(GMT%s) %s", offsetName, tz.city),
+			"offset_name":     offsetName,
+			"offset":          tz.offset,
+		})
+	}
+}
+
+func (s *Server) seedCampaigns(rng *rand.Rand, now time.Time, groups []string) {
+	day := 24 * time.Hour
+	campaign := func(name, subject string, groupIDs ...string) record {
+		var ids []interface{}
+		for _, id := range groupIDs {
+			ids = append(ids, id)
+		}
+		return s.insert("campaigns", s.newCampaign(record{
+			"name": name,
+			"type": "regular",
+			"emails": []interface{}{map[string]interface{}{
+				"subject":   subject,
+				"from_name": "Demo Store",
+				"from":      "news@example.com",
+				"content":   "<html><body><h1>" + subject + "</h1><p>Hello {$name},</p></body></html>",
+			}},
+			"groups": ids,
+		}))
+	}
+	setTimes := func(rec record, created time.Time) {
+		rec["created_at"] = created.Format(timeLayout)
+		rec["updated_at"] = rec["created_at"]
+		email := rec["emails"].([]interface{})[0].(record)
+		email["created_at"], email["updated_at"] = rec["created_at"], rec["created_at"]
+	}
+
+	for i, c := range [][2]string{
+		{"January newsletter", "What's new in January"},
+		{"Spring sale", "20% off everything this weekend"},
+		{"Product update", "Meet the new dashboard"},
+	} {
+		rec := campaign(c[0], c[1], groups[0])
+		sentAt := now.Add(-time.Duration(60-i*20) * day)
+		setTimes(rec, sentAt.Add(-2*day))
+
+		var opens, clicks, unsubscribes int
+		path := "campaigns/" + stringOf(rec["id"]) + "/subscriber-activity"
+		for _, sub := range s.recipients(rec) {
+			o, cl := 0, 0
+			if rng.Float64() < 0.45 {
+				o = 1 + rng.IntN(3)
+				if rng.Float64() < 0.3 {
+					cl = 1 + rng.IntN(2)
+				}
+			}
+			s.insert(path, record{"subscriber_id": sub["id"], "opens_count": o, "clicks_count": cl, "created_at": sentAt.Format(timeLayout)})
+			opens, clicks = opens+min(o, 1), clicks+min(cl, 1)
+		}
+		sent := len(s.coll(path).items)
+		if sent > 0 {
+			unsubscribes = rng.IntN(3)
+		}
+
+		at := sentAt.Format(timeLayout)
+		rec["status"] = "sent"
+		rec["delivery_schedule"] = "instant"
+		rec["queued_at"], rec["started_at"], rec["finished_at"] = at, at, at
+		rec["can_be_scheduled"] = false
+		rec["stats"] = campaignStats(sent, opens, clicks, unsubscribes)
+		rec["emails"].([]interface{})[0].(record)["stats"] = rec["stats"]
+	}
+
+	for _, c := range [][2]string{
+		{"Summer teaser", "Something big is coming"},
+		{"Customer survey", "Tell us what you think"},
+	} {
+		setTimes(campaign(c[0], c[1], groups[1]), now.Add(-3*day))
+	}
+
+	rec := campaign("Webinar invitation", "Join our live webinar", groups[3])
+	setTimes(rec, now.Add(-day))
+	rec["status"] = "ready"
+	rec["delivery_schedule"] = "scheduled"
+	rec["scheduled_for"] = now.Add(7 * day).Format(timeLayout)
+	rec["can_be_scheduled"] = false
+}
+
+func (s *Server) seedAutomations(rng *rand.Rand, now time.Time, groups []string) {
+	day := 24 * time.Hour
+	created := now.Add(-180 * day).Format(timeLayout)
+	step := func(typ, parent, description string) record {
+		return record{
+			"id": s.newID(), "type": typ, "parent_id": parent, "complete": true, "broken": false,
+			"description": description, "created_at": created, "updated_at": created,
+		}
+	}
+	email := func(parent, name, subject string) record {
+		st := step("email", parent, name)
+		st["name"], st["subject"] = name, subject
+		st["from"], st["from_name"] = "news@example.com", "Demo Store"
+		st["email_id"], st["language_id"], st["track_opens"] = s.newID(), 1, true
+		return st
+	}
+	delay := func(parent string, days int) record {
+		st := step("delay", parent, fmt.Sprintf("Wait %d days", days))
+		st["unit"], st["value"] = "days", stringOf(days)
+		return st
+	}
+	activityStatuses := []string{"completed", "active", "canceled", "failed"}
+
+	for i, a := range []struct {
+		name    string
+		enabled bool
+		group   int
+		emails  [][2]string
+	}{
+		{"Welcome series", true, 0, [][2]string{{"Welcome", "Welcome aboard!"}, {"Getting started", "Three tips to get started"}}},
+		{"Abandoned cart", true, 1, [][2]string{{"Cart reminder", "You left something behind"}}},
+		{"Win-back", false, 4, [][2]string{{"We miss you", "It's been a while"}, {"Last chance", "A gift before you go"}}},
+	} {
+		var steps []interface{}
+		parent := ""
+		for j, e := range a.emails {
+			if j > 0 {
+				d := delay(parent, 3)
+				steps = append(steps, d)
+				parent = stringOf(d["id"])
+			}
+			st := email(parent, e[0], e[1])
+			steps = append(steps, st)
+			parent = stringOf(st["id"])
+		}
+
+		sent := rng.IntN(2000)
+		opens := rng.IntN(sent + 1)
+		clicks := rng.IntN(opens + 1)
+		stats := campaignStats(sent, opens, clicks, rng.IntN(10))
+		stats["completed_subscribers_count"] = sent / len(a.emails)
+		stats["subscribers_in_queue_count"] = rng.IntN(50)
+		stats["bounce_rate"] = rate(0, float64(sent))
+		delete(stats, "forwards_count")
+
+		group := s.coll("groups").find(groups[a.group])
+		rec := s.insert("automations", record{
+			"name":         a.name,
+			"enabled":      a.enabled,
+			"trigger_data": record{"track_ecommerce": i == 1, "repeatable": false, "valid": true},
+			"steps":        steps,
+			"triggers": []interface{}{record{
+				"id": s.newID(), "type": "subscriber_joins_group", "group_id": group["id"],
+				"group":             record{"id": group["id"], "name": group["name"], "url": nil},
+				"exclude_group_ids": []interface{}{}, "excluded_groups": []interface{}{}, "broken": false,
+			}},
+			"complete":                    true,
+			"broken":                      false,
+			"warnings":                    []interface{}{},
+			"emails_count":                len(a.emails),
+			"first_email_screenshot_url":  nil,
+			"stats":                       stats,
+			"created_at":                  created,
+			"has_banned_content":          false,
+			"qualified_subscribers_count": 0,
+		})
+
+		// Activity of the first members of the trigger group.
+		path := "automations/" + stringOf(rec["id"]) + "/activity"
+		first, last := steps[0].(record), steps[len(steps)-1].(record)
+		for _, sub := range s.coll("subscribers").items {
+			if len(s.coll(path).items) == 20 {
+				break
+			}
+			if !inGroup(sub, groups[a.group]) {
+				continue
+			}
+			status := activityStatuses[rng.IntN(len(activityStatuses))]
+			var reason interface{}
+			description := ""
+			if status == "canceled" {
+				reason, description = "unsubscribed", "Subscriber unsubscribed"
+			}
+			s.insert(path, record{
+				"status":             status,
+				"date":               now.Add(-time.Duration(rng.IntN(90)) * day).Format(timeLayout),
+				"reason":             reason,
+				"reason_description": description,
+				"subscriber":         record{"id": sub["id"], "email": sub["email"]},
+				"stepRuns":           []interface{}{},
+				"nextStep":           last,
+				"currentStep":        first,
+			})
+		}
+	}
+}
+
+func (s *Server) seedShop(rng *rand.Rand, now time.Time) {
+	created := now.Add(-120 * 24 * time.Hour).Format(timeLayout)
+	shop := s.insert("ecommerce/shops", record{"name": "Demo Store", "url": "https://shop.example.com", "created_at": created})
+	path := "ecommerce/shops/" + stringOf(shop["id"])
+
+	var categories []string
+	for _, name := range []string{"Apparel", "Accessories"} {
+		c := s.insert(path+"/categories", record{"name": name, "created_at": created})
+		categories = append(categories, stringOf(c["id"]))
+	}
+	var products []record
+	for i, p := range []struct {
+		name     string
+		price    float64
+		category int
+	}{
+		{"Classic T-shirt", 19.99, 0}, {"Hoodie", 49, 0}, {"Rain jacket", 89.5, 0},
+		{"Canvas tote", 14.5, 1}, {"Water bottle", 24, 1}, {"Sticker pack", 4.99, 1},
+	} {
+		slug := fieldKey(p.name)
+		rec := s.insert(path+"/products", record{
+			"name":        p.name,
+			"price":       p.price,
+			"url":         "https://shop.example.com/products/" + slug,
+			"image_url":   "https://shop.example.com/images/" + slug + ".jpg",
+			"description": "",
+			"quantity":    10 + i*7,
+			"created_at":  created,
+		})
+		products = append(products, rec)
+		key := path + "/categories/" + categories[p.category] + "/products"
+		s.members[key] = append(s.members[key], stringOf(rec["id"]))
+	}
+
+	var customers []string
+	for _, sub := range s.coll("subscribers").items[:min(5, len(s.coll("subscribers").items))] {
+		fields := sub["fields"].(map[string]interface{})
+		c := s.insert(path+"/customers", record{
+			"email": sub["email"], "first_name": fields["name"], "last_name": fields["last_name"], "created_at": created,
+		})
+		customers = append(customers, stringOf(c["id"]))
+	}
+	if len(customers) == 0 {
+		return
+	}
+
+	items := func(n int) []interface{} {
+		var out []interface{}
+		for _, j := range rng.Perm(len(products))[:n] {
+			out = append(out, record{"product_id": products[j]["id"], "quantity": 1 + rng.IntN(3), "price": products[j]["price"]})
+		}
+		return out
+	}
+	statuses := []string{"complete", "complete", "paid", "pending", "refunded", "complete"}
+	for i, status := range statuses {
+		orderItems := items(1 + rng.IntN(3))
+		s.insert(path+"/orders", record{
+			"customer_id": customers[i%len(customers)],
+			"status":      status,
+			"currency":    "EUR",
+			"items":       orderItems,
+			"total":       math.Round(itemsTotal(orderItems)*100) / 100,
+			"created_at":  now.Add(-time.Duration(len(statuses)-i) * 24 * time.Hour).Format(timeLayout),
+		})
+	}
+	for i := 0; i < 2; i++ {
+		cart := s.insert(path+"/carts", record{"customer_id": customers[i%len(customers)], "currency": "EUR", "total": 0, "created_at": created})
+		for _, item := range items(1 + i) {
+			s.insert(path+"/carts/"+stringOf(cart["id"])+"/items", item.(record))
+		}
+	}
+}
+
+// percent returns part as a percentage of total, rounded to two decimals.
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*10000) / 100
+}
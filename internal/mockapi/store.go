@@ -0,0 +1,613 @@
+package mockapi
+
+import (
+	"cmp"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// record is a stored resource in its JSON form.
+type record = map[string]interface{}
+
+// collection is an ordered list of records of one resource.
+type collection struct {
+	items []record
+}
+
+func (s *Server) coll(path string) *collection {
+	c, ok := s.collections[path]
+	if !ok {
+		c = &collection{}
+		s.collections[path] = c
+	}
+	return c
+}
+
+func (c *collection) index(id string) int {
+	return slices.IndexFunc(c.items, func(r record) bool { return r["id"] == id })
+}
+
+func (c *collection) find(id string) record {
+	if i := c.index(id); i >= 0 {
+		return c.items[i]
+	}
+	return nil
+}
+
+func (c *collection) remove(id string) bool {
+	i := c.index(id)
+	if i < 0 {
+		return false
+	}
+	c.items = slices.Delete(c.items, i, i+1)
+	return true
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(timeLayout)
+}
+
+// timeLayout is the API's date-time format.
+const timeLayout = "2006-01-02 15:04:05"
+
+// insert assigns an ID, unless rec has one, and timestamps to rec and
+// appends it to the collection at path.
+func (s *Server) insert(path string, rec record) record {
+	if _, ok := rec["id"]; !ok {
+		rec["id"] = s.newID()
+	}
+	if _, ok := rec["created_at"]; !ok {
+		rec["created_at"] = s.timestamp()
+	}
+	rec["updated_at"] = rec["created_at"]
+	c := s.coll(path)
+	c.items = append(c.items, rec)
+	return rec
+}
+
+// --- generic handlers ---
+
+// resource describes how one kind of record is validated, created and
+// presented.
+type resource struct {
+	// validate checks a create (create is true) or update body.
+	validate func(v *validation, body record, create bool)
+	// build returns a new record from a valid create body.
+	build func(body record) record
+	// view returns the record as served, e.g. with computed counts. nil
+	// serves records as stored.
+	view func(rec record) record
+}
+
+func (res resource) present(rec record) record {
+	if res.view == nil {
+		return rec
+	}
+	return res.view(maps.Clone(rec))
+}
+
+func (s *Server) list(req *request, path string, res resource, filterable ...string) (int, interface{}) {
+	return listRecords(req, s.coll(path).items, res, filterable...)
+}
+
+// listRecords filters, presents, sorts and paginates items.
+func listRecords(req *request, items []record, res resource, filterable ...string) (int, interface{}) {
+	items, errResp := filterRecords(req, items, filterable...)
+	if errResp != nil {
+		return http.StatusUnprocessableEntity, errResp
+	}
+	views := make([]record, len(items))
+	for i, item := range items {
+		views[i] = res.present(item)
+	}
+	if errResp := sortRecords(req, views); errResp != nil {
+		return http.StatusUnprocessableEntity, errResp
+	}
+	return paginate(req, views)
+}
+
+func (s *Server) get(path, id string, res resource) (int, interface{}) {
+	rec := s.coll(path).find(id)
+	if rec == nil {
+		return notFound()
+	}
+	return http.StatusOK, data(res.present(rec))
+}
+
+func (s *Server) create(req *request, path string, res resource) (int, interface{}) {
+	v := &validation{}
+	res.validate(v, req.body, true)
+	if v.failed() {
+		return v.response()
+	}
+	rec := s.insert(path, res.build(req.body))
+	return http.StatusCreated, data(res.present(rec))
+}
+
+func (s *Server) update(req *request, path, id string, res resource) (int, interface{}) {
+	rec := s.coll(path).find(id)
+	if rec == nil {
+		return notFound()
+	}
+	v := &validation{}
+	res.validate(v, req.body, false)
+	if v.failed() {
+		return v.response()
+	}
+	for k, val := range req.body {
+		if _, ok := rec[k]; ok && k != "id" {
+			rec[k] = val
+		}
+	}
+	rec["updated_at"] = s.timestamp()
+	return http.StatusOK, data(res.present(rec))
+}
+
+func (s *Server) delete(path, id string) (int, interface{}) {
+	if !s.coll(path).remove(id) {
+		return notFound()
+	}
+	return http.StatusNoContent, nil
+}
+
+// --- pagination ---
+
+const (
+	defaultLimit = 25
+	maxLimit     = 1000
+)
+
+// param returns a request parameter from the query string or, for the
+// endpoints the SDK calls with POST, from the JSON body, where its options
+// are encoded with capitalised keys.
+func (req *request) param(name string) (string, bool) {
+	if q := req.URL.Query(); q.Has(name) {
+		return q.Get(name), true
+	}
+	for k, v := range req.body {
+		if strings.EqualFold(k, name) && v != nil {
+			return stringOf(v), true
+		}
+	}
+	return "", false
+}
+
+// intParam returns an integer parameter, def if it is missing or zero-valued
+// in a JSON body.
+func (req *request) intParam(v *validation, name string, def int) int {
+	s, ok := req.param(name)
+	if !ok || s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		v.add(name, fmt.Sprintf("The %s must be an integer.", name))
+		return def
+	}
+	return n
+}
+
+// limit returns the page size. ok is false when limit=0 was requested,
+// which the API answers with just the total count.
+func (req *request) limit(v *validation) (int, bool) {
+	if q := req.URL.Query(); q.Has("limit") && q.Get("limit") == "0" {
+		return 0, false
+	}
+	limit := req.intParam(v, "limit", defaultLimit)
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit < 1 || limit > maxLimit {
+		v.add("limit", fmt.Sprintf("The limit must be between 1 and %d.", maxLimit))
+	}
+	return limit, true
+}
+
+func count(n int) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{"total": n}
+}
+
+// paginate serves one page of items with page-based links and meta.
+func paginate(req *request, items []record) (int, interface{}) {
+	v := &validation{}
+	limit, ok := req.limit(v)
+	if !ok {
+		return count(len(items))
+	}
+	page := req.intParam(v, "page", 1)
+	if page < 1 {
+		v.add("page", "The page must be at least 1.")
+	}
+	if v.failed() {
+		return v.response()
+	}
+
+	lastPage := max(1, int(math.Ceil(float64(len(items))/float64(limit))))
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+
+	link := func(p int) interface{} {
+		if p < 1 || p > lastPage {
+			return nil
+		}
+		return pageURL(req, "page", strconv.Itoa(p))
+	}
+	var from, to interface{}
+	if end > start {
+		from, to = start+1, end
+	}
+	return http.StatusOK, map[string]interface{}{
+		"data": orEmpty(items[start:end]),
+		"links": map[string]interface{}{
+			"first": link(1),
+			"last":  link(lastPage),
+			"prev":  link(page - 1),
+			"next":  link(page + 1),
+		},
+		"meta": map[string]interface{}{
+			"current_page": page,
+			"from":         from,
+			"last_page":    lastPage,
+			"path":         pageURL(req, "", ""),
+			"per_page":     limit,
+			"to":           to,
+			"total":        len(items),
+		},
+	}
+}
+
+// paginateCursor serves one page of items with the opaque string cursors
+// used for subscribers.
+func paginateCursor(req *request, items []record) (int, interface{}) {
+	v := &validation{}
+	limit, ok := req.limit(v)
+	if !ok {
+		return count(len(items))
+	}
+	start := 0
+	if cursor, _ := req.param("cursor"); cursor != "" {
+		b, err := base64.RawURLEncoding.DecodeString(cursor)
+		n, convErr := strconv.Atoi(string(b))
+		if err != nil || convErr != nil || n < 0 || n > len(items) {
+			v.add("cursor", "The cursor is invalid.")
+		}
+		start = n
+	}
+	if v.failed() {
+		return v.response()
+	}
+
+	end := min(start+limit, len(items))
+	var next, prev interface{}
+	if end < len(items) {
+		next = encodeCursor(end)
+	}
+	if start > 0 {
+		prev = encodeCursor(max(0, start-limit))
+	}
+	link := func(cursor interface{}) interface{} {
+		if cursor == nil {
+			return nil
+		}
+		return pageURL(req, "cursor", cursor.(string))
+	}
+	return http.StatusOK, map[string]interface{}{
+		"data": orEmpty(items[start:end]),
+		"links": map[string]interface{}{
+			"first": nil,
+			"last":  nil,
+			"prev":  link(prev),
+			"next":  link(next),
+		},
+		"meta": map[string]interface{}{
+			"path":        pageURL(req, "", ""),
+			"per_page":    limit,
+			"next_cursor": next,
+			"prev_cursor": prev,
+		},
+	}
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// paginateAfter serves the items whose numeric ID follows the "after"
+// parameter, as segment subscriber lists do. meta.last is the cursor for the
+// next page, or 0 on the last page.
+func paginateAfter(req *request, items []record) (int, interface{}) {
+	v := &validation{}
+	limit, ok := req.limit(v)
+	if !ok {
+		return count(len(items))
+	}
+	after := req.intParam(v, "after", 0)
+	if v.failed() {
+		return v.response()
+	}
+
+	start := 0
+	if after > 0 {
+		start = len(items)
+		for i, item := range items {
+			if id, _ := strconv.Atoi(stringOf(item["id"])); id > after {
+				start = i
+				break
+			}
+		}
+	}
+	end := min(start+limit, len(items))
+	last := 0
+	if end < len(items) {
+		last, _ = strconv.Atoi(stringOf(items[end-1]["id"]))
+	}
+	return http.StatusOK, map[string]interface{}{
+		"data": orEmpty(items[start:end]),
+		"meta": map[string]interface{}{
+			"count": end - start,
+			"last":  last,
+			"total": len(items),
+		},
+	}
+}
+
+// pageURL returns the request URL with key set to value, or without any
+// query if key is empty.
+func pageURL(req *request, key, value string) string {
+	u := url.URL{Scheme: "http", Host: req.Host, Path: req.URL.Path}
+	if key != "" {
+		q := req.URL.Query()
+		q.Set(key, value)
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+func orEmpty(items []record) []record {
+	if items == nil {
+		return []record{}
+	}
+	return items
+}
+
+// --- filtering and sorting ---
+
+// filterRecords applies filter[key]=value query parameters. Only the keys in
+// filterable are accepted; "name" matches case-insensitive substrings and
+// every other key matches exactly.
+func filterRecords(req *request, items []record, filterable ...string) ([]record, *apiError) {
+	v := &validation{}
+	out := items
+	for key, values := range req.URL.Query() {
+		field, ok := strings.CutPrefix(key, "filter[")
+		if !ok {
+			continue
+		}
+		field = strings.TrimSuffix(field, "]")
+		if !slices.Contains(filterable, field) {
+			v.add(key, fmt.Sprintf("Filtering by %s is not supported.", field))
+			continue
+		}
+		want := values[0]
+		out = slices.DeleteFunc(slices.Clone(out), func(r record) bool {
+			got := stringOf(r[field])
+			if field == "name" {
+				return !strings.Contains(strings.ToLower(got), strings.ToLower(want))
+			}
+			return got != want
+		})
+	}
+	if v.failed() {
+		return nil, v.apiError()
+	}
+	return out, nil
+}
+
+// sortRecords applies the sort parameter, a field name optionally prefixed
+// with "-" for descending order.
+func sortRecords(req *request, items []record) *apiError {
+	sortBy, _ := req.param("sort")
+	if sortBy == "" {
+		return nil
+	}
+	field, desc := strings.CutPrefix(sortBy, "-")
+	if len(items) > 0 {
+		if _, ok := items[0][field]; !ok {
+			return &apiError{
+				Message: "The selected sort is invalid.",
+				Errors:  map[string][]string{"sort": {"The selected sort is invalid."}},
+			}
+		}
+	}
+	slices.SortStableFunc(items, func(a, b record) int {
+		c := compareValues(a[field], b[field])
+		if desc {
+			return -c
+		}
+		return c
+	})
+	return nil
+}
+
+func compareValues(a, b interface{}) int {
+	x, okA := number(a)
+	y, okB := number(b)
+	if okA && okB {
+		return cmp.Compare(x, y)
+	}
+	return cmp.Compare(stringOf(a), stringOf(b))
+}
+
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func stringOf(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// --- validation ---
+
+// validation collects field errors in the order they were found.
+type validation struct {
+	fields []string
+	errors map[string][]string
+}
+
+func (v *validation) add(field, msg string) {
+	if v.errors == nil {
+		v.errors = map[string][]string{}
+	}
+	if _, ok := v.errors[field]; !ok {
+		v.fields = append(v.fields, field)
+	}
+	v.errors[field] = append(v.errors[field], msg)
+}
+
+func (v *validation) failed() bool {
+	return len(v.errors) > 0
+}
+
+// response returns a 422 with the validation errors.
+func (v *validation) response() (int, interface{}) {
+	return http.StatusUnprocessableEntity, v.apiError()
+}
+
+// apiError returns the errors in the API's format, where the message is the
+// first error and a count of the rest.
+func (v *validation) apiError() *apiError {
+	msg := v.errors[v.fields[0]][0]
+	rest := -1
+	for _, msgs := range v.errors {
+		rest += len(msgs)
+	}
+	switch {
+	case rest == 1:
+		msg += " (and 1 more error)"
+	case rest > 1:
+		msg += fmt.Sprintf(" (and %d more errors)", rest)
+	}
+	return &apiError{Message: msg, Errors: v.errors}
+}
+
+func label(field string) string {
+	return strings.ReplaceAll(field, "_", " ")
+}
+
+// present reports whether body has a non-empty value for field.
+func present(body record, field string) bool {
+	switch val := body[field].(type) {
+	case nil:
+		return false
+	case string:
+		return strings.TrimSpace(val) != ""
+	case []interface{}:
+		return len(val) > 0
+	}
+	return true
+}
+
+// check validates field if it is given and, when required is set, that it
+// is given. It returns whether the value should be checked further.
+func (v *validation) check(body record, field string, required bool) bool {
+	if present(body, field) {
+		return true
+	}
+	if _, given := body[field]; required || given {
+		v.add(field, fmt.Sprintf("The %s field is required.", label(field)))
+	}
+	return false
+}
+
+func (v *validation) str(body record, field string, required bool, maxLen int) {
+	if !v.check(body, field, required) {
+		return
+	}
+	s, ok := body[field].(string)
+	switch {
+	case !ok:
+		v.add(field, fmt.Sprintf("The %s must be a string.", label(field)))
+	case len(s) > maxLen:
+		v.add(field, fmt.Sprintf("The %s must not be greater than %d characters.", label(field), maxLen))
+	}
+}
+
+func (v *validation) email(body record, field string, required bool) {
+	if !v.check(body, field, required) {
+		return
+	}
+	s, _ := body[field].(string)
+	if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+		v.add(field, fmt.Sprintf("The %s must be a valid email address.", label(field)))
+	}
+}
+
+func (v *validation) url(body record, field string, required bool) {
+	if !v.check(body, field, required) {
+		return
+	}
+	s, _ := body[field].(string)
+	if u, err := url.Parse(s); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(field, fmt.Sprintf("The %s must be a valid URL.", label(field)))
+	}
+}
+
+func (v *validation) oneOf(body record, field string, required bool, allowed ...string) {
+	if !v.check(body, field, required) {
+		return
+	}
+	if !slices.Contains(allowed, stringOf(body[field])) {
+		v.add(field, fmt.Sprintf("The selected %s is invalid.", label(field)))
+	}
+}
+
+func (v *validation) number(body record, field string, required bool, minimum float64) {
+	if !v.check(body, field, required) {
+		return
+	}
+	n, ok := number(body[field])
+	switch {
+	case !ok:
+		v.add(field, fmt.Sprintf("The %s must be a number.", label(field)))
+	case n < minimum:
+		v.add(field, fmt.Sprintf("The %s must be at least %s.", label(field), stringOf(minimum)))
+	}
+}
+
+// exists checks that field refers to a record in c.
+func (v *validation) exists(body record, field string, required bool, c *collection) {
+	if !v.check(body, field, required) {
+		return
+	}
+	if c.find(stringOf(body[field])) == nil {
+		v.add(field, fmt.Sprintf("The selected %s is invalid.", label(field)))
+	}
+}
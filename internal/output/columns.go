@@ -0,0 +1,174 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mailerlite/mailerlite-cli/internal/query"
+)
+
+// Column is a named table column for items of type T. Name is what users
+// pass to --columns and --sort-by; it matches the JSON key where there is one.
+type Column[T any] struct {
+	Name   string
+	Header string
+	Value  func(T) string
+}
+
+// ColumnSet is the column registry for one resource: every column that can
+// be selected with --columns, and the ones shown when none are selected.
+//
+// Besides the registered columns, any dotted name such as "fields.city" or
+// "stats.open_rate.string" selects the value at that path in the item's JSON
+// representation.
+type ColumnSet[T any] struct {
+	columns  []Column[T]
+	defaults []string
+}
+
+// NewColumnSet creates a column registry. defaults lists the columns shown
+// when --columns is not given, in order.
+func NewColumnSet[T any](defaults []string, columns []Column[T]) ColumnSet[T] {
+	s := ColumnSet[T]{columns: columns, defaults: defaults}
+	for _, name := range defaults {
+		if _, ok := s.registered(name); !ok {
+			panic(fmt.Sprintf("output: default column %q is not registered", name))
+		}
+	}
+	return s
+}
+
+// WithDefaults returns a copy of the set with different default columns,
+// for commands that show the same resource with a different emphasis.
+func (s ColumnSet[T]) WithDefaults(names ...string) ColumnSet[T] {
+	return NewColumnSet(names, s.columns)
+}
+
+// Names returns the names of all registered columns.
+func (s ColumnSet[T]) Names() []string {
+	names := make([]string, len(s.columns))
+	for i, c := range s.columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Lookup resolves a column name, case-insensitively.
+func (s ColumnSet[T]) Lookup(name string) (Column[T], error) {
+	if c, ok := s.registered(name); ok {
+		return c, nil
+	}
+	if strings.Contains(name, ".") {
+		return pathColumn[T](name), nil
+	}
+	return Column[T]{}, fmt.Errorf("unknown column %q: available columns are %s (or a JSON path such as fields.name)", name, strings.Join(s.Names(), ", "))
+}
+
+// Select resolves the columns to display. An empty list selects the defaults.
+func (s ColumnSet[T]) Select(names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		names = s.defaults
+	}
+	cols := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		c, err := s.Lookup(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
+
+func (s ColumnSet[T]) registered(name string) (Column[T], bool) {
+	for _, c := range s.columns {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	return Column[T]{}, false
+}
+
+// pathColumn selects a value by dotted path from an item's JSON form.
+func pathColumn[T any](path string) Column[T] {
+	keys := strings.Split(path, ".")
+	return Column[T]{
+		Name:   path,
+		Header: strings.ToUpper(path),
+		Value: func(item T) string {
+			v, err := query.ToGeneric(item)
+			if err != nil {
+				return ""
+			}
+			for _, k := range keys {
+				m, ok := v.(map[string]interface{})
+				if !ok {
+					return ""
+				}
+				v = m[k]
+			}
+			return scalarString(v)
+		},
+	}
+}
+
+// headers returns the column headers.
+func headers[T any](cols []Column[T]) []string {
+	out := make([]string, len(cols))
+	for i, c := range cols {
+		out[i] = c.Header
+	}
+	return out
+}
+
+// row returns the cells of one item.
+func row[T any](cols []Column[T], item T) []string {
+	out := make([]string, len(cols))
+	for i, c := range cols {
+		out[i] = c.Value(item)
+	}
+	return out
+}
+
+// sortColumn resolves a --sort-by value. A leading "-" sorts in descending
+// order.
+func (s ColumnSet[T]) sortColumn(sortBy string) (Column[T], bool, error) {
+	name, desc := strings.CutPrefix(sortBy, "-")
+	col, err := s.Lookup(name)
+	return col, desc, err
+}
+
+// sortItems sorts items by a column value, numerically when both values are
+// numbers.
+func sortItems[T any](items []T, col Column[T], desc bool) {
+	keys := make([]string, len(items))
+	idx := make([]int, len(items))
+	for i, item := range items {
+		idx[i] = i
+		keys[i] = col.Value(item)
+	}
+	slices.SortStableFunc(idx, func(a, b int) int {
+		c := compareCells(keys[a], keys[b])
+		if desc {
+			return -c
+		}
+		return c
+	})
+	sorted := make([]T, len(items))
+	for i, j := range idx {
+		sorted[i] = items[j]
+	}
+	copy(items, sorted)
+}
+
+func compareCells(a, b string) int {
+	x, errA := strconv.ParseFloat(strings.TrimSuffix(a, "%"), 64)
+	y, errB := strconv.ParseFloat(strings.TrimSuffix(b, "%"), 64)
+	if errA == nil && errB == nil {
+		return cmp.Compare(x, y)
+	}
+	return cmp.Compare(a, b)
+}
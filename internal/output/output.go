@@ -3,6 +3,7 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"iter"
 	"os"
 	"strings"
 
@@ -44,27 +45,68 @@ func JSON(v interface{}) error {
 	return enc.Encode(v)
 }
 
+// JSONList streams items from seq to stdout as an indented JSON array,
+// writing each item as soon as it is received. If seq yields an error the
+// array is left unterminated and the error is returned.
+func JSONList[T any](seq iter.Seq2[T, error]) error {
+	n := 0
+	for item, err := range seq {
+		if err != nil {
+			if n > 0 {
+				fmt.Println()
+			}
+			return err
+		}
+		b, err := json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		sep := ",\n  "
+		if n == 0 {
+			sep = "[\n  "
+		}
+		if _, err := fmt.Print(sep + string(b)); err != nil {
+			return err
+		}
+		n++
+	}
+	if n == 0 {
+		fmt.Println("[]")
+		return nil
+	}
+	fmt.Println("\n]")
+	return nil
+}
+
 func Table(headers []string, rows [][]string) {
+	renderTable(headers, rows, true)
+}
+
+func renderTable(headers []string, rows [][]string, showHeaders bool) {
 	if len(rows) == 0 {
-		fmt.Println(style(DimStyle, "No results found."))
+		if showHeaders {
+			fmt.Println(style(DimStyle, "No results found."))
+		}
 		return
 	}
 
 	if noColor {
-		printPlainTable(headers, rows)
+		printPlainTable(headers, rows, showHeaders)
 		return
 	}
 
 	t := table.New().
 		Border(lipgloss.NormalBorder()).
 		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
-		Headers(headers...).
 		StyleFunc(func(row, col int) lipgloss.Style {
 			if row == table.HeaderRow {
 				return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12")).Padding(0, 1)
 			}
 			return lipgloss.NewStyle().Padding(0, 1)
 		})
+	if showHeaders {
+		t.Headers(headers...)
+	}
 
 	for _, row := range rows {
 		t.Row(row...)
@@ -73,10 +115,12 @@ func Table(headers []string, rows [][]string) {
 	fmt.Println(t)
 }
 
-func printPlainTable(headers []string, rows [][]string) {
+func printPlainTable(headers []string, rows [][]string, showHeaders bool) {
 	widths := make([]int, len(headers))
-	for i, h := range headers {
-		widths[i] = len(h)
+	if showHeaders {
+		for i, h := range headers {
+			widths[i] = len(h)
+		}
 	}
 	for _, row := range rows {
 		for i, cell := range row {
@@ -87,10 +131,12 @@ func printPlainTable(headers []string, rows [][]string) {
 	}
 
 	// Header
-	for i, h := range headers {
-		fmt.Printf("%-*s", widths[i]+2, strings.ToUpper(h))
+	if showHeaders {
+		for i, h := range headers {
+			fmt.Printf("%-*s", widths[i]+2, strings.ToUpper(h))
+		}
+		fmt.Println()
 	}
-	fmt.Println()
 
 	// Rows
 	for _, row := range rows {
@@ -0,0 +1,379 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/mailerlite/mailerlite-cli/internal/query"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output.
+const (
+	FormatTable    = "table"
+	FormatJSON     = "json"
+	FormatJSONL    = "jsonl"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatYAML     = "yaml"
+	FormatTemplate = "template"
+)
+
+// Formats lists the names accepted by ParseFormat, for help text and completion.
+var Formats = []string{FormatTable, FormatJSON, FormatJSONL, FormatCSV, FormatTSV, FormatYAML, FormatTemplate + "=..."}
+
+// maxCellWidth is the width at which table cells are truncated. Other
+// formats always receive the full value.
+const maxCellWidth = 50
+
+// Format holds the parsed output flags: the --output format, an optional
+// --query expression applied before rendering, and the column options used
+// by list commands.
+type Format struct {
+	Name     string
+	Template *template.Template
+	Query    *query.Expression
+
+	// Columns selects and orders table, CSV and TSV columns (--columns).
+	Columns []string
+	// SortBy sorts list output by a column; "-name" sorts descending.
+	SortBy string
+	// NoHeaders omits the header row from table, CSV and TSV output.
+	NoHeaders bool
+}
+
+// ParseFormat parses an --output value. Templates are given inline as
+// "template={{.email}}" or loaded from a file with "template=@path".
+func ParseFormat(s string) (Format, error) {
+	name, arg, hasArg := strings.Cut(s, "=")
+	switch name {
+	case "", FormatTable:
+		return Format{Name: FormatTable}, nil
+	case FormatJSON, FormatJSONL, FormatCSV, FormatTSV, FormatYAML:
+		if hasArg {
+			return Format{}, fmt.Errorf("output format %q does not take a value", name)
+		}
+		return Format{Name: name}, nil
+	case FormatTemplate:
+		if arg == "" {
+			return Format{}, fmt.Errorf("--output template requires a template, e.g. template='{{.id}}'")
+		}
+		if path, ok := strings.CutPrefix(arg, "@"); ok {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return Format{}, fmt.Errorf("failed to read template: %w", err)
+			}
+			arg = string(b)
+		}
+		tmpl, err := template.New("output").Funcs(templateFuncs).Parse(arg)
+		if err != nil {
+			return Format{}, fmt.Errorf("invalid template: %w", err)
+		}
+		return Format{Name: FormatTemplate, Template: tmpl}, nil
+	default:
+		return Format{}, fmt.Errorf("unknown output format %q: use one of %s", name, strings.Join(Formats, ", "))
+	}
+}
+
+// IsTable reports whether f is the human-readable default format.
+func (f Format) IsTable() bool {
+	return f.Name == "" || f.Name == FormatTable
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"join":     joinAny,
+	"truncate": func(n int, s string) string { return Truncate(s, n) },
+}
+
+func joinAny(sep string, v interface{}) string {
+	items, ok := v.([]interface{})
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = scalarString(item)
+	}
+	return strings.Join(parts, sep)
+}
+
+// Print renders a single value, such as the result of a get, create or
+// update command. Table output falls back to indented JSON; commands that
+// have a human-readable view print it themselves instead of calling Print.
+// JSONL and template output render each element of a top-level array
+// separately.
+func Print(f Format, v interface{}) error {
+	if f.Query != nil {
+		generic, err := query.ToGeneric(v)
+		if err != nil {
+			return err
+		}
+		if v, err = f.Query.Search(generic); err != nil {
+			return err
+		}
+	}
+
+	switch f.Name {
+	case FormatJSONL:
+		enc := json.NewEncoder(os.Stdout)
+		return eachElement(v, func(item interface{}) error { return enc.Encode(item) })
+	case FormatYAML:
+		return writeYAML(os.Stdout, v)
+	case FormatCSV, FormatTSV:
+		generic, err := query.ToGeneric(v)
+		if err != nil {
+			return err
+		}
+		records, ok := generic.([]interface{})
+		if !ok {
+			records = []interface{}{generic}
+		}
+		return writeRecords(os.Stdout, f.Name, records)
+	case FormatTemplate:
+		return eachElement(v, func(item interface{}) error { return executeTemplate(os.Stdout, f.Template, item) })
+	default:
+		return JSON(v)
+	}
+}
+
+// eachElement calls fn for every element of v if it is a slice (of any
+// type), and for v itself otherwise.
+func eachElement(v interface{}, fn func(interface{}) error) error {
+	generic, err := query.ToGeneric(v)
+	if err != nil {
+		return err
+	}
+	items, ok := generic.([]interface{})
+	if !ok {
+		return fn(generic)
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List renders a stream of items. The column set defines the table, CSV
+// and TSV columns; the other formats render the items themselves. Every
+// format except table writes each item as soon as it arrives, unless the
+// items must be sorted or queried first.
+func List[T any](f Format, set ColumnSet[T], seq iter.Seq2[T, error]) error {
+	cols, err := set.Select(f.Columns)
+	if err != nil {
+		return err
+	}
+
+	if f.SortBy != "" || f.Query != nil {
+		var sortCol Column[T]
+		var desc bool
+		if f.SortBy != "" {
+			if sortCol, desc, err = set.sortColumn(f.SortBy); err != nil {
+				return err
+			}
+		}
+		items := []T{}
+		if err := eachItem(seq, func(item T) error {
+			items = append(items, item)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if f.SortBy != "" {
+			sortItems(items, sortCol, desc)
+		}
+		if f.Query != nil {
+			return Print(f, items)
+		}
+		seq = Items(items)
+	}
+
+	switch f.Name {
+	case FormatJSON:
+		return JSONList(seq)
+	case FormatJSONL:
+		enc := json.NewEncoder(os.Stdout)
+		return eachItem(seq, func(item T) error { return enc.Encode(item) })
+	case FormatYAML:
+		n := 0
+		err := eachItem(seq, func(item T) error {
+			n++
+			return writeYAML(os.Stdout, []T{item})
+		})
+		if err == nil && n == 0 {
+			fmt.Println("[]")
+		}
+		return err
+	case FormatCSV, FormatTSV:
+		w := newDelimitedWriter(os.Stdout, f.Name)
+		if !f.NoHeaders {
+			if err := w.Write(headers(cols)); err != nil {
+				return err
+			}
+		}
+		err := eachItem(seq, func(item T) error {
+			if err := w.Write(row(cols, item)); err != nil {
+				return err
+			}
+			w.Flush()
+			return w.Error()
+		})
+		w.Flush()
+		return err
+	case FormatTemplate:
+		return eachItem(seq, func(item T) error { return executeTemplate(os.Stdout, f.Template, item) })
+	default:
+		var rows [][]string
+		err := eachItem(seq, func(item T) error {
+			cells := row(cols, item)
+			for i, cell := range cells {
+				cells[i] = Truncate(cell, maxCellWidth)
+			}
+			rows = append(rows, cells)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		renderTable(headers(cols), rows, !f.NoHeaders)
+		return nil
+	}
+}
+
+// Items adapts a slice to the iterator accepted by List.
+func Items[T any](items []T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func eachItem[T any](seq iter.Seq2[T, error], fn func(T) error) error {
+	for item, err := range seq {
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeYAML(w io.Writer, v interface{}) error {
+	generic, err := query.ToGeneric(v)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(generic); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func executeTemplate(w io.Writer, tmpl *template.Template, v interface{}) error {
+	generic, err := query.ToGeneric(v)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, generic); err != nil {
+		return err
+	}
+	out := b.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
+
+func newDelimitedWriter(w io.Writer, format string) *csv.Writer {
+	cw := csv.NewWriter(w)
+	if format == FormatTSV {
+		cw.Comma = '\t'
+	}
+	return cw
+}
+
+// writeRecords writes JSON objects as CSV/TSV, using the union of their
+// top-level keys as columns. Nested values are written as JSON.
+func writeRecords(w io.Writer, format string, records []interface{}) error {
+	keySet := make(map[string]bool)
+	for _, r := range records {
+		if m, ok := r.(map[string]interface{}); ok {
+			for k := range m {
+				keySet[k] = true
+			}
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for k := range keySet {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	cw := newDelimitedWriter(w, format)
+	if len(keys) == 0 {
+		// Not objects: write one value per line.
+		for _, r := range records {
+			if err := cw.Write([]string{scalarString(r)}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	if err := cw.Write(keys); err != nil {
+		return err
+	}
+	for _, r := range records {
+		m, _ := r.(map[string]interface{})
+		row := make([]string, len(keys))
+		for i, k := range keys {
+			row[i] = scalarString(m[k])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func scalarString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(val)
+		return string(b)
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,133 @@
+// Package query evaluates JMESPath (https://jmespath.org) expressions for
+// filtering command output with --query, using go-jmespath.
+//
+// Expressions operate on the generic values produced by encoding/json:
+// map[string]interface{}, []interface{}, float64, string, bool and nil.
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// Expression is a compiled JMESPath expression.
+type Expression struct {
+	src string
+	jp  *jmespath.JMESPath
+}
+
+// Compile parses a JMESPath expression.
+func Compile(expr string) (*Expression, error) {
+	jp, err := jmespath.Compile(expr)
+	if err != nil {
+		var syntaxErr jmespath.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, &SyntaxError{
+				Expression: expr,
+				Offset:     syntaxErr.Offset,
+				Msg:        strings.TrimPrefix(syntaxErr.Error(), "SyntaxError: "),
+			}
+		}
+		return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+	}
+	return &Expression{src: expr, jp: jp}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.src
+}
+
+// Search evaluates the expression against data, which must be made of the
+// generic values produced by encoding/json.
+func (e *Expression) Search(data interface{}) (interface{}, error) {
+	v, err := e.jp.Search(data)
+	if err != nil {
+		return nil, fmt.Errorf("query %q: %w", e.src, err)
+	}
+	v, err = toResult(v)
+	if err != nil {
+		return nil, fmt.Errorf("query %q: %w", e.src, err)
+	}
+	return v, nil
+}
+
+// toResult makes a search result printable as JSON. go-jmespath returns NaN
+// for the average of an empty array, which the specification says is null,
+// and returns expression references such as &foo as values instead of
+// rejecting them.
+func toResult(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, bool, string:
+		return v, nil
+	case float64:
+		if math.IsNaN(v) {
+			return nil, nil
+		}
+		return v, nil
+	case []interface{}:
+		for i, item := range v {
+			r, err := toResult(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = r
+		}
+		return v, nil
+	case map[string]interface{}:
+		for k, item := range v {
+			r, err := toResult(item)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = r
+		}
+		return v, nil
+	}
+	return nil, errors.New("expression reference cannot be used as a result")
+}
+
+// Search compiles expr and evaluates it against v, which may be any value
+// that can be marshalled to JSON.
+func Search(expr string, v interface{}) (interface{}, error) {
+	e, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ToGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+	return e.Search(data)
+}
+
+// ToGeneric converts v to the plain maps and slices produced by
+// encoding/json, so that expressions see the same keys as JSON output.
+func ToGeneric(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// SyntaxError reports an invalid expression and the offset at which
+// parsing failed.
+type SyntaxError struct {
+	Expression string
+	Offset     int
+	Msg        string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid query %q at position %d: %s", e.Expression, e.Offset+1, e.Msg)
+}
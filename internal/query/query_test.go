@@ -0,0 +1,671 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// The cases below are taken from the JMESPath compliance suite
+// (https://github.com/jmespath/jmespath.test), grouped by its test files.
+// Each case is an expression, the JSON it is expected to evaluate to, or
+// the kind of error it is expected to fail with. Cases that go-jmespath
+// does not pass are kept with the reason they are skipped.
+
+type complianceCase struct {
+	expr   string
+	result string
+	err    string // "syntax" or "runtime"
+	skip   string
+}
+
+type complianceSuite struct {
+	name  string
+	given string
+	cases []complianceCase
+}
+
+var complianceSuites = []complianceSuite{
+	{
+		name:  "basic",
+		given: `{"foo": {"bar": {"baz": "correct"}}}`,
+		cases: []complianceCase{
+			{expr: `foo`, result: `{"bar": {"baz": "correct"}}`},
+			{expr: `foo.bar`, result: `{"baz": "correct"}`},
+			{expr: `foo.bar.baz`, result: `"correct"`},
+			{expr: "foo\n.\nbar\n.baz", result: `"correct"`},
+			{expr: `foo.bar.baz.bad`, result: `null`},
+			{expr: `foo.bar.bad`, result: `null`},
+			{expr: `foo.bad`, result: `null`},
+			{expr: `bad`, result: `null`},
+			{expr: `bad.morebad.morebad`, result: `null`},
+		},
+	},
+	{
+		name:  "basic arrays",
+		given: `{"foo": {"bar": ["one", "two", "three"]}}`,
+		cases: []complianceCase{
+			{expr: `foo`, result: `{"bar": ["one", "two", "three"]}`},
+			{expr: `foo.bar`, result: `["one", "two", "three"]`},
+		},
+	},
+	{
+		name:  "basic array root",
+		given: `["one", "two", "three"]`,
+		cases: []complianceCase{
+			{expr: `one`, result: `null`},
+			{expr: `two`, result: `null`},
+			{expr: `three`, result: `null`},
+			{expr: `one.two`, result: `null`},
+		},
+	},
+	{
+		name:  "basic keys",
+		given: `{"foo": {"1": ["one", "two", "three"], "-1": "bar"}}`,
+		cases: []complianceCase{
+			{expr: `foo."1"`, result: `["one", "two", "three"]`},
+			{expr: `foo."1"[0]`, result: `"one"`},
+			{expr: `foo."-1"`, result: `"bar"`},
+		},
+	},
+	{
+		name: "boolean",
+		given: `{"outer": {"foo": "foo", "bar": "bar", "baz": "baz"},
+			"True": true, "False": false, "Number": 5, "EmptyList": [], "Zero": 0}`,
+		cases: []complianceCase{
+			{expr: `outer.foo || outer.bar`, result: `"foo"`},
+			{expr: `outer.foo||outer.bar`, result: `"foo"`},
+			{expr: `outer.bar || outer.baz`, result: `"bar"`},
+			{expr: `outer.bad || outer.foo`, result: `"foo"`},
+			{expr: `outer.foo || outer.bad`, result: `"foo"`},
+			{expr: `outer.bad || outer.alsobad`, result: `null`},
+			{expr: `True && False`, result: `false`},
+			{expr: `False && True`, result: `false`},
+			{expr: `True && True`, result: `true`},
+			{expr: `False && False`, result: `false`},
+			{expr: `True && Number`, result: `5`},
+			{expr: `Number && True`, result: `true`},
+			{expr: `Number && False`, result: `false`},
+			{expr: `Number && EmptyList`, result: `[]`},
+			{expr: `EmptyList && True`, result: `[]`},
+			{expr: `EmptyList && False`, result: `[]`},
+			{expr: `True || False`, result: `true`},
+			{expr: `True || True`, result: `true`},
+			{expr: `False || True`, result: `true`},
+			{expr: `False || False`, result: `false`},
+			{expr: `Number || EmptyList`, result: `5`},
+			{expr: `Number || True`, result: `5`},
+			{expr: `EmptyList || Number`, result: `5`},
+			{expr: `!True`, result: `false`},
+			{expr: `!False`, result: `true`},
+			{expr: `!Number`, result: `false`},
+			{expr: `!EmptyList`, result: `true`},
+			{expr: `True && !False`, result: `true`},
+			{expr: `True && !EmptyList`, result: `true`},
+			{expr: `!False && !EmptyList`, result: `true`},
+			{expr: `!(True && False)`, result: `true`},
+			{expr: `!Zero`, result: `false`},
+			{expr: `!!Zero`, result: `true`},
+		},
+	},
+	{
+		name:  "comparators",
+		given: `{"one": 1, "two": 2, "three": 3, "emptylist": [], "emptyobj": {}, "string": "abc"}`,
+		cases: []complianceCase{
+			{expr: `one < two`, result: `true`},
+			{expr: `one <= two`, result: `true`},
+			{expr: `one == one`, result: `true`},
+			{expr: `one == two`, result: `false`},
+			{expr: `one > two`, result: `false`},
+			{expr: `one >= two`, result: `false`},
+			{expr: `one != two`, result: `true`},
+			{expr: `one < two && three > one`, result: `true`},
+			{expr: `one < two || three > one`, result: `true`},
+			{expr: `one < two || three < one`, result: `true`},
+			{expr: `two < one || three < one`, result: `false`},
+			{expr: `emptylist == emptylist`, result: `true`},
+			{expr: `emptyobj == emptyobj`, result: `true`},
+			{expr: `string < one`, result: `null`},
+			{expr: `string == 'abc'`, result: `true`},
+		},
+	},
+	{
+		name:  "current node",
+		given: `{"foo": [{"name": "a"}, {"name": "b"}], "bar": {"baz": "qux"}}`,
+		cases: []complianceCase{
+			{expr: `@`, result: `{"foo": [{"name": "a"}, {"name": "b"}], "bar": {"baz": "qux"}}`},
+			{expr: `@.bar`, result: `{"baz": "qux"}`},
+			{expr: `@.foo[0]`, result: `{"name": "a"}`},
+		},
+	},
+	{
+		name: "escape",
+		given: `{"foo.bar": "dot", "foo bar": "space", "foo\nbar": "newline",
+			"foo\"bar": "doublequote", "c:\\\\windows\\path": "windows", "/unix/path": "unix",
+			"\"\"\"": "threequotes", "bar": {"baz": "qux"}}`,
+		cases: []complianceCase{
+			{expr: `"foo.bar"`, result: `"dot"`},
+			{expr: `"foo bar"`, result: `"space"`},
+			{expr: `"foo\nbar"`, result: `"newline"`},
+			{expr: `"foo\"bar"`, result: `"doublequote"`},
+			{expr: `"c:\\\\windows\\path"`, result: `"windows"`},
+			{expr: `"/unix/path"`, result: `"unix"`},
+			{expr: `"\"\"\""`, result: `"threequotes"`},
+			{expr: `"bar"."baz"`, result: `"qux"`},
+		},
+	},
+	{
+		name: "filters",
+		given: `{"foo": [{"name": "a"}, {"name": "b"}],
+			"bar": [{"age": 20, "name": "x"}, {"age": 25, "name": "y"}, {"age": 30, "name": "z"}],
+			"mixed": [{"key": "a"}, {"key": 1}, {"key": null}, {"other": true}]}`,
+		cases: []complianceCase{
+			{expr: `foo[?name == 'a']`, result: `[{"name": "a"}]`},
+			{expr: `*[?[0] == ` + "`0`" + `]`, result: `[[], [], []]`},
+			{expr: `foo[?name == 'c']`, result: `[]`},
+			{expr: `bar[?age > ` + "`20`" + `].name`, result: `["y", "z"]`},
+			{expr: `bar[?age >= ` + "`25`" + `].name`, result: `["y", "z"]`},
+			{expr: `bar[?age < ` + "`25`" + `].name`, result: `["x"]`},
+			{expr: `bar[?age <= ` + "`25`" + `].name`, result: `["x", "y"]`},
+			{expr: `bar[?age != ` + "`25`" + `].name`, result: `["x", "z"]`},
+			{expr: `bar[?age > ` + "`20`" + ` && age < ` + "`30`" + `].name`, result: `["y"]`},
+			{expr: `bar[?age == ` + "`20`" + ` || age == ` + "`30`" + `].name`, result: `["x", "z"]`},
+			{expr: `bar[?!(age == ` + "`25`" + `)].name`, result: `["x", "z"]`},
+			{expr: `mixed[?key].key`, result: `["a", 1]`},
+			{expr: `mixed[?key == ` + "`null`" + `]`, result: `[{"key": null}, {"other": true}]`},
+			{expr: `mixed[?key > ` + "`0`" + `]`, result: `[{"key": 1}]`},
+			{expr: `bar[?name == 'y'] | [0].age`, result: `25`},
+			{expr: `bar[?age > ` + "`20`" + `][0]`, result: `[]`},
+			{expr: `bar[?age > ` + "`20`" + `] | [0].name`, result: `"y"`},
+		},
+	},
+	{
+		name: "functions",
+		given: `{"foo": -1, "zero": 0, "numbers": [-1, 3, 4, 5], "array": ["a", "b"],
+			"strings": ["a", "b", "c"], "decimals": [1.01, 1.2, -1.5], "str": "Str",
+			"false": false, "empty_list": [], "empty_hash": {}, "objects": {"foo": "bar", "bar": "baz"},
+			"null_key": null,
+			"people": [{"age": 20, "name": "b"}, {"age": 40, "name": "a"}, {"age": 30, "name": "c"}]}`,
+		cases: []complianceCase{
+			{expr: `abs(foo)`, result: `1`},
+			{expr: `abs(array[1])`, err: "runtime"},
+			{expr: `abs(` + "`-24`" + `)`, result: `24`},
+			{expr: `abs(` + "`1`, `2`" + `)`, err: "runtime"},
+			{expr: `abs()`, err: "runtime"},
+			{expr: `unknown_function(` + "`1`, `2`" + `)`, err: "runtime"},
+			{expr: `avg(numbers)`, result: `2.75`},
+			{expr: `avg(array)`, err: "runtime"},
+			{expr: `avg(empty_list)`, result: `null`},
+			{expr: `ceil(` + "`1.2`" + `)`, result: `2`},
+			{expr: `ceil(decimals[0])`, result: `2`},
+			{expr: `ceil(decimals[2])`, result: `-1`},
+			{expr: `ceil('string')`, err: "runtime"},
+			{expr: `contains('abc', 'a')`, result: `true`},
+			{expr: `contains('abc', 'd')`, result: `false`},
+			{expr: `contains(` + "`false`" + `, 'd')`, err: "runtime"},
+			{expr: `contains(strings, 'a')`, result: `true`},
+			{expr: `contains(decimals, ` + "`1.01`" + `)`, result: `true`},
+			{expr: `contains(decimals, ` + "`false`" + `)`, result: `false`},
+			{expr: `ends_with(str, 'r')`, result: `true`},
+			{expr: `ends_with(str, 'tr')`, result: `true`},
+			{expr: `ends_with(str, 'Str')`, result: `true`},
+			{expr: `ends_with(str, 'SStr')`, result: `false`},
+			{expr: `ends_with(str, 'foo')`, result: `false`},
+			{expr: `ends_with(str, ` + "`0`" + `)`, err: "runtime"},
+			{expr: `floor(` + "`1.2`" + `)`, result: `1`},
+			{expr: `floor('string')`, err: "runtime"},
+			{expr: `floor(decimals[0])`, result: `1`},
+			{expr: `floor(foo)`, result: `-1`},
+			{expr: `join(', ', strings)`, result: `"a, b, c"`},
+			{expr: `join(', ', ` + "`[\"a\", \"b\"]`" + `)`, result: `"a, b"`},
+			{expr: `join(',', ` + "`[\"a\", 0]`" + `)`, err: "runtime"},
+			{expr: `join(', ', str)`, err: "runtime"},
+			{expr: `join('|', strings)`, result: `"a|b|c"`},
+			{expr: `join(', ', empty_list)`, result: `""`},
+			{expr: `keys(foo)`, err: "runtime"},
+			{expr: `keys(strings)`, err: "runtime"},
+			{expr: `keys(empty_hash)`, result: `[]`},
+			{expr: `sort(keys(objects))`, result: `["bar", "foo"]`},
+			{expr: `sort(values(objects))`, result: `["bar", "baz"]`},
+			{expr: `length('abc')`, result: `3`},
+			{expr: `length('✓foo')`, result: `4`},
+			{expr: `length('')`, result: `0`},
+			{expr: `length(@)`, result: `13`},
+			{expr: `length(strings[0])`, result: `1`},
+			{expr: `length(str)`, result: `3`},
+			{expr: `length(array)`, result: `2`},
+			{expr: `length(objects)`, result: `2`},
+			{expr: `length(` + "`false`" + `)`, err: "runtime"},
+			{expr: `length(foo)`, err: "runtime"},
+			{expr: `max(numbers)`, result: `5`},
+			{expr: `max(decimals)`, result: `1.2`},
+			{expr: `max(strings)`, result: `"c"`},
+			{expr: `max(abc)`, err: "runtime"},
+			{expr: `max(array)`, result: `"b"`},
+			{expr: `max(empty_list)`, result: `null`},
+			{expr: `merge(` + "`{}`" + `)`, result: `{}`},
+			{expr: `merge(` + "`{}`, `{}`" + `)`, result: `{}`},
+			{expr: `merge(` + "`{\"a\": 1}`, `{\"b\": 2}`" + `)`, result: `{"a": 1, "b": 2}`},
+			{expr: `merge(` + "`{\"a\": 1}`, `{\"a\": 2}`" + `)`, result: `{"a": 2}`},
+			{expr: `merge(` + "`{\"a\": 1, \"b\": 2}`, `{\"a\": 2, \"c\": 3}`, `{\"d\": 4}`" + `)`, result: `{"a": 2, "b": 2, "c": 3, "d": 4}`},
+			{expr: `min(numbers)`, result: `-1`},
+			{expr: `min(decimals)`, result: `-1.5`},
+			{expr: `min(abc)`, err: "runtime"},
+			{expr: `min(empty_list)`, result: `null`},
+			{expr: `min(strings)`, result: `"a"`},
+			{expr: `type('abc')`, result: `"string"`},
+			{expr: `type(` + "`1.0`" + `)`, result: `"number"`},
+			{expr: `type(` + "`2`" + `)`, result: `"number"`},
+			{expr: `type(` + "`true`" + `)`, result: `"boolean"`},
+			{expr: `type(` + "`false`" + `)`, result: `"boolean"`},
+			{expr: `type(` + "`null`" + `)`, result: `"null"`},
+			{expr: `type(` + "`[0]`" + `)`, result: `"array"`},
+			{expr: `type(` + "`{\"a\": \"b\"}`" + `)`, result: `"object"`},
+			{expr: `type(@)`, result: `"object"`},
+			{expr: `sort(keys(empty_hash))`, result: `[]`},
+			{expr: `reverse(array)`, result: `["b", "a"]`},
+			{expr: `reverse(empty_list)`, result: `[]`},
+			{expr: `reverse('')`, result: `""`},
+			{expr: `reverse('hello world')`, result: `"dlrow olleh"`},
+			{expr: `starts_with(str, 'S')`, result: `true`},
+			{expr: `starts_with(str, 'St')`, result: `true`},
+			{expr: `starts_with(str, 'Str')`, result: `true`},
+			{expr: `starts_with(str, 'String')`, result: `false`},
+			{expr: `starts_with(str, ` + "`0`" + `)`, err: "runtime"},
+			{expr: `sum(numbers)`, result: `11`},
+			{expr: `sum(decimals)`, result: `0.71`},
+			{expr: `sum(array)`, err: "runtime"},
+			{expr: `sum(array[].to_number(@))`, result: `0`},
+			{expr: `sum(` + "`[]`" + `)`, result: `0`},
+			{expr: `to_array('foo')`, result: `["foo"]`},
+			{expr: `to_array(` + "`0`" + `)`, result: `[0]`},
+			{expr: `to_array(objects)`, result: `[{"foo": "bar", "bar": "baz"}]`},
+			{expr: `to_array(` + "`[1, 2, 3]`" + `)`, result: `[1, 2, 3]`},
+			{expr: `to_array(` + "`false`" + `)`, result: `[false]`},
+			{expr: `to_string('foo')`, result: `"foo"`},
+			{expr: `to_string(` + "`1.2`" + `)`, result: `"1.2"`},
+			{expr: `to_string(` + "`[0, 1]`" + `)`, result: `"[0,1]"`},
+			{expr: `to_number('1.0')`, result: `1.0`},
+			{expr: `to_number('1.1')`, result: `1.1`},
+			{expr: `to_number('4')`, result: `4`},
+			{expr: `to_number('notanumber')`, result: `null`},
+			{expr: `to_number(` + "`false`" + `)`, result: `null`},
+			{expr: `to_number(` + "`null`" + `)`, result: `null`},
+			{expr: `to_number(` + "`[0]`" + `)`, result: `null`},
+			{expr: `to_number(` + "`{\"foo\": 0}`" + `)`, result: `null`},
+			{expr: `"to_string"(` + "`1.0`" + `)`, err: "syntax"},
+			{expr: `sort(numbers)`, result: `[-1, 3, 4, 5]`},
+			{expr: `sort(strings)`, result: `["a", "b", "c"]`},
+			{expr: `sort(decimals)`, result: `[-1.5, 1.01, 1.2]`},
+			{expr: `sort(array)`, result: `["a", "b"]`},
+			{expr: `sort(abc)`, err: "runtime"},
+			{expr: `sort(empty_list)`, result: `[]`},
+			{expr: `sort(@)`, err: "runtime"},
+			{expr: `not_null(unknown_key, str)`, result: `"Str"`},
+			{expr: `not_null(unknown_key, foo.bar, empty_list, str)`, result: `[]`},
+			{expr: `not_null(unknown_key, null_key, empty_list, str)`, result: `[]`},
+			{expr: `not_null(all, expressions, are_null)`, result: `null`},
+			{expr: `not_null()`, err: "runtime"},
+			{expr: `sort_by(people, &age)[].name`, result: `["b", "c", "a"]`},
+			{expr: `sort_by(people, &name)[].age`, result: `[40, 20, 30]`},
+			{expr: `sort_by(people, &age)[0]`, result: `{"age": 20, "name": "b"}`},
+			{expr: `sort_by(people, name)`, err: "runtime"},
+			{expr: `max_by(people, &age)`, result: `{"age": 40, "name": "a"}`},
+			{expr: `max_by(people, &age).age`, result: `40`},
+			{expr: `max_by(people, &name)`, result: `{"age": 30, "name": "c"}`},
+			{expr: `min_by(people, &age)`, result: `{"age": 20, "name": "b"}`},
+			{expr: `min_by(people, &age).age`, result: `20`},
+			{expr: `min_by(people, &name)`, result: `{"age": 40, "name": "a"}`},
+			{expr: `map(&name, people)`, result: `["b", "a", "c"]`},
+			{expr: `map(&age, people)`, result: `[20, 40, 30]`},
+			{expr: `map(&foo, people)`, result: `[null, null, null]`},
+			{expr: `map(&[], ` + "`[[1, 2, 3, [4]], [5, 6, 7, [8, 9]]]`" + `)`, result: `[[1, 2, 3, 4], [5, 6, 7, 8, 9]]`},
+		},
+	},
+	{
+		name:  "identifiers",
+		given: `{"__L": true, "_a_": "underscores", "a1": 1, "A_B": "mixed", "foo": {"bar": {"baz": "qux"}}}`,
+		cases: []complianceCase{
+			{expr: `__L`, result: `true`},
+			{expr: `_a_`, result: `"underscores"`},
+			{expr: `a1`, result: `1`},
+			{expr: `A_B`, result: `"mixed"`},
+			{expr: `"__L"`, result: `true`},
+			{expr: `foo.bar.baz`, result: `"qux"`},
+		},
+	},
+	{
+		name:  "indices",
+		given: `{"foo": {"bar": ["zero", "one", "two"]}}`,
+		cases: []complianceCase{
+			{expr: `foo.bar[0]`, result: `"zero"`},
+			{expr: `foo.bar[1]`, result: `"one"`},
+			{expr: `foo.bar[2]`, result: `"two"`},
+			{expr: `foo.bar[3]`, result: `null`},
+			{expr: `foo.bar[-1]`, result: `"two"`},
+			{expr: `foo.bar[-2]`, result: `"one"`},
+			{expr: `foo.bar[-3]`, result: `"zero"`},
+			{expr: `foo.bar[-4]`, result: `null`},
+			{expr: `foo[0]`, result: `null`},
+		},
+	},
+	{
+		name:  "nested indices",
+		given: `{"foo": [{"bar": ["one", "two"]}, {"bar": ["three", "four"]}, {"notbar": ["five"]}]}`,
+		cases: []complianceCase{
+			{expr: `foo.bar`, result: `null`},
+			{expr: `foo[0].bar`, result: `["one", "two"]`},
+			{expr: `foo[1].bar`, result: `["three", "four"]`},
+			{expr: `foo[2].bar`, result: `null`},
+			{expr: `foo[3].notbar`, result: `null`},
+			{expr: `foo[0].bar[0]`, result: `"one"`},
+		},
+	},
+	{
+		name:  "flatten",
+		given: `{"foo": [{"bar": [{"qux": 2, "baz": 1}, {"qux": 4, "baz": 3}]}, {"bar": [{"qux": 6, "baz": 5}, {"qux": 8, "baz": 7}]}]}`,
+		cases: []complianceCase{
+			{expr: `foo[]`, result: `[{"bar": [{"qux": 2, "baz": 1}, {"qux": 4, "baz": 3}]}, {"bar": [{"qux": 6, "baz": 5}, {"qux": 8, "baz": 7}]}]`},
+			{expr: `foo[].bar`, result: `[[{"qux": 2, "baz": 1}, {"qux": 4, "baz": 3}], [{"qux": 6, "baz": 5}, {"qux": 8, "baz": 7}]]`},
+			{expr: `foo[].bar[]`, result: `[{"qux": 2, "baz": 1}, {"qux": 4, "baz": 3}, {"qux": 6, "baz": 5}, {"qux": 8, "baz": 7}]`},
+			{expr: `foo[].bar[].baz`, result: `[1, 3, 5, 7]`},
+			{expr: `foo[].bar[0]`, result: `[{"qux": 2, "baz": 1}, {"qux": 6, "baz": 5}]`},
+			{expr: `foo[].bar[].[baz, qux]`, result: `[[1, 2], [3, 4], [5, 6], [7, 8]]`},
+			{expr: `foo[].bar[].[baz]`, result: `[[1], [3], [5], [7]]`},
+			{expr: `foo[].bar[].[baz, qux][]`, result: `[1, 2, 3, 4, 5, 6, 7, 8]`},
+		},
+	},
+	{
+		name:  "flatten nested",
+		given: `{"foo": [[1, 2], [3, [4, 5]]], "bar": "string"}`,
+		cases: []complianceCase{
+			{expr: `foo[]`, result: `[1, 2, 3, [4, 5]]`},
+			{expr: `foo[][]`, result: `[1, 2, 3, 4, 5]`},
+			{expr: `bar[]`, result: `null`},
+			{expr: `[]`, result: `null`},
+		},
+	},
+	{
+		name:  "literal",
+		given: `{"foo": [{"name": "a"}, {"name": "b"}], "bar": {"baz": "qux"}}`,
+		cases: []complianceCase{
+			{expr: "`\"foo\"`", result: `"foo"`},
+			{expr: "`\"\\u03a6\"`", result: `"Φ"`},
+			{expr: "`\"✓\"`", result: `"✓"`},
+			{expr: "`[1, 2, 3]`", result: `[1, 2, 3]`},
+			{expr: "`{\"a\": \"b\"}`", result: `{"a": "b"}`},
+			{expr: "`true`", result: `true`},
+			{expr: "`false`", result: `false`},
+			{expr: "`null`", result: `null`},
+			{expr: "`0`", result: `0`},
+			{expr: "`1`", result: `1`},
+			{expr: "`-1`", result: `-1`},
+			{expr: "`1.5`", result: `1.5`},
+			{expr: "`\"abc\"`", result: `"abc"`},
+			{expr: "`{\"a\": \"b\"}`.a", result: `"b"`},
+			{expr: "`[0, 1, 2]`[1]", result: `1`},
+			{expr: "`{\"b\": \"`\"}`", err: "syntax"},
+			{expr: `'foo'`, result: `"foo"`},
+			{expr: `'  foo  '`, result: `"  foo  "`},
+			{expr: `'0'`, result: `"0"`},
+			{expr: `'newline` + "\n" + `'`, result: `"newline\n"`},
+			{expr: `'\''`, result: `"'"`},
+			{expr: `'\z'`, result: `"\\z"`},
+			{expr: `'\\'`, result: `"\\\\"`, skip: "go-jmespath does not unescape backslashes in raw strings"},
+			{expr: `'\u03a6'`, result: `"\\u03a6"`},
+			{expr: `'✓'`, result: `"✓"`},
+		},
+	},
+	{
+		name:  "multiselect",
+		given: `{"foo": {"bar": "bar", "baz": "baz", "qux": "qux", "nested": {"one": {"a": "first", "b": "second"}, "two": {"a": "third", "b": "fourth"}}}}`,
+		cases: []complianceCase{
+			{expr: `foo.{bar: bar}`, result: `{"bar": "bar"}`},
+			{expr: `foo.{"bar": bar}`, result: `{"bar": "bar"}`},
+			{expr: `foo.{"foo.bar": bar}`, result: `{"foo.bar": "bar"}`},
+			{expr: `foo.{bar: bar, baz: baz}`, result: `{"bar": "bar", "baz": "baz"}`},
+			{expr: `foo.{bar: bar, qux: qux}`, result: `{"bar": "bar", "qux": "qux"}`},
+			{expr: `foo.{bar: bar, noexist: noexist}`, result: `{"bar": "bar", "noexist": null}`},
+			{expr: `foo.{noexist: noexist, alsonoexist: alsonoexist}`, result: `{"noexist": null, "alsonoexist": null}`},
+			{expr: `foo.badkey.{nokey: nokey, alsonokey: alsonokey}`, result: `null`},
+			{expr: `foo.nested.*.{a: a,b: b}`, result: `[{"a": "first", "b": "second"}, {"a": "third", "b": "fourth"}]`, skip: "go-jmespath projects object values in random map order"},
+			{expr: `foo.nested.three.{a: a, cinner: c.inner}`, result: `null`},
+			{expr: `foo.[bar]`, result: `["bar"]`},
+			{expr: `foo.[bar,baz]`, result: `["bar", "baz"]`},
+			{expr: `foo.[bar,noexist]`, result: `["bar", null]`},
+			{expr: `foo.[noexist,alsonoexist]`, result: `[null, null]`},
+			{expr: `foo.badkey.[bar]`, result: `null`},
+			{expr: `{foo: foo.bar, baz: foo.baz}`, result: `{"foo": "bar", "baz": "baz"}`},
+			{expr: `[foo.bar, foo.baz]`, result: `["bar", "baz"]`},
+			{expr: `foo.{bar: bar`, err: "syntax"},
+			{expr: `foo.[bar`, err: "syntax"},
+			{expr: `foo.{bar}`, err: "syntax"},
+		},
+	},
+	{
+		name:  "pipe",
+		given: `{"foo": {"bar": {"baz": "subkey"}, "other": {"baz": "subkey"}, "other2": {"baz": "subkey"}, "other3": {"notbaz": ["a", "b", "c"]}, "other4": {"notbaz": ["a", "b", "c"]}}}`,
+		cases: []complianceCase{
+			{expr: `foo.*.baz | [0]`, result: `"subkey"`},
+			{expr: `foo.*.baz | [1]`, result: `"subkey"`},
+			{expr: `foo.*.baz | [2]`, result: `"subkey"`},
+			{expr: `foo.bar.* | [0]`, result: `"subkey"`},
+			{expr: `foo.*.notbaz | [*]`, result: `[["a", "b", "c"], ["a", "b", "c"]]`},
+			{expr: `{"a": foo.bar, "b": foo.other} | *.baz`, result: `["subkey", "subkey"]`},
+			{expr: `foo | bar`, result: `{"baz": "subkey"}`},
+			{expr: `foo | bar | baz`, result: `"subkey"`},
+			{expr: `foo|bar| baz`, result: `"subkey"`},
+			{expr: `not_there | [0]`, result: `null`},
+			{expr: `[foo.bar, foo.other] | [0]`, result: `{"baz": "subkey"}`},
+			{expr: `foo.bar.baz | ` + "`\"x\"`", result: `"x"`},
+			{expr: `foo |`, err: "syntax"},
+		},
+	},
+	{
+		name:  "slice",
+		given: `{"foo": [0, 1, 2, 3, 4, 5, 6, 7, 8, 9], "bar": {"baz": 1}}`,
+		cases: []complianceCase{
+			{expr: `bar[0:10]`, result: `null`},
+			{expr: `foo[0:10:1]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[0:10]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[0:10:]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[0::1]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[0::]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[0:]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[:10:1]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[::1]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[:10:]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[::]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[:]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[1:9]`, result: `[1, 2, 3, 4, 5, 6, 7, 8]`},
+			{expr: `foo[0:10:2]`, result: `[0, 2, 4, 6, 8]`},
+			{expr: `foo[5:]`, result: `[5, 6, 7, 8, 9]`},
+			{expr: `foo[5::2]`, result: `[5, 7, 9]`},
+			{expr: `foo[::2]`, result: `[0, 2, 4, 6, 8]`},
+			{expr: `foo[::-1]`, result: `[9, 8, 7, 6, 5, 4, 3, 2, 1, 0]`},
+			{expr: `foo[1::2]`, result: `[1, 3, 5, 7, 9]`},
+			{expr: `foo[10:0:-1]`, result: `[9, 8, 7, 6, 5, 4, 3, 2, 1]`},
+			{expr: `foo[10:5:-1]`, result: `[9, 8, 7, 6]`},
+			{expr: `foo[8:2:-2]`, result: `[8, 6, 4]`},
+			{expr: `foo[0:20]`, result: `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+			{expr: `foo[10:-20:-1]`, result: `[9, 8, 7, 6, 5, 4, 3, 2, 1, 0]`},
+			{expr: `foo[-4:-1]`, result: `[6, 7, 8]`},
+			{expr: `foo[:-5:-1]`, result: `[9, 8, 7, 6]`},
+			{expr: `foo[8:2:0]`, err: "runtime"},
+			{expr: `foo[8:2:0:1]`, err: "syntax"},
+			{expr: `foo[8:2&]`, err: "syntax"},
+			{expr: `foo[2:a:3]`, err: "syntax"},
+		},
+	},
+	{
+		name:  "slice projection",
+		given: `{"foo": [{"a": 1}, {"a": 2}, {"a": 3}], "bar": [{"a": {"b": 1}}, {"a": {"b": 2}}, {"a": {"b": 3}}], "baz": 50}`,
+		cases: []complianceCase{
+			{expr: `foo[:2].a`, result: `[1, 2]`},
+			{expr: `foo[:2].b`, result: `[]`},
+			{expr: `foo[:2].a.b`, result: `[]`},
+			{expr: `bar[::-1].a.b`, result: `[3, 2, 1]`},
+			{expr: `bar[:2].a.b`, result: `[1, 2]`},
+			{expr: `baz[:2].a`, result: `null`},
+		},
+	},
+	{
+		name:  "syntax",
+		given: `{"type": "object"}`,
+		cases: []complianceCase{
+			{expr: `foo.1`, err: "syntax"},
+			{expr: `foo.-11`, err: "syntax"},
+			{expr: `foo.`, err: "syntax"},
+			{expr: `.foo`, err: "syntax"},
+			{expr: `foo..bar`, err: "syntax"},
+			{expr: `foo.bar.`, err: "syntax"},
+			{expr: `foo[.]`, err: "syntax"},
+			{expr: `.`, err: "syntax"},
+			{expr: `:`, err: "syntax"},
+			{expr: `,`, err: "syntax"},
+			{expr: `]`, err: "syntax"},
+			{expr: `[`, err: "syntax"},
+			{expr: `}`, err: "syntax"},
+			{expr: `{`, err: "syntax"},
+			{expr: `)`, err: "syntax"},
+			{expr: `(`, err: "syntax"},
+			{expr: `((&`, err: "syntax"},
+			{expr: `a[`, err: "syntax"},
+			{expr: `a]`, err: "syntax"},
+			{expr: `a][`, err: "syntax"},
+			{expr: `!`, err: "syntax"},
+			{expr: `@=`, err: "syntax"},
+			{expr: `*.*`, result: `[]`},
+			{expr: `*.foo`, result: `[]`},
+			{expr: `*[0]`, result: `[]`},
+			{expr: `foo[bar]`, err: "syntax"},
+			{expr: `foo[?]`, err: "syntax"},
+			{expr: `foo[?bar==]`, err: "syntax"},
+			{expr: `foo[?==]`, err: "syntax"},
+			{expr: `foo[?==bar]`, err: "syntax"},
+			{expr: `foo[?bar==baz?]`, err: "syntax"},
+			{expr: `foo || `, err: "syntax"},
+			{expr: `foo && `, err: "syntax"},
+			{expr: `"foo`, err: "syntax"},
+			{expr: `'foo`, err: "syntax"},
+			{expr: "`foo", err: "syntax"},
+			{expr: `foo[0, 1]`, err: "syntax"},
+			{expr: `foo.[0]`, err: "syntax"},
+			{expr: `foo.{a: b`, err: "syntax"},
+			{expr: `foo.{a b}`, err: "syntax"},
+			{expr: `foo.{a: b,}`, err: "syntax"},
+			{expr: `foo.[a, b,]`, err: "syntax"},
+			{expr: `&foo`, err: "runtime"},
+		},
+	},
+	{
+		name:  "unicode",
+		given: `{"foo": [{"✓": "✓"}, {"✓": "✗"}], "☯": true, "♪♫•*¨*•.¸¸❤¸¸.•*¨*•♫♪": true}`,
+		cases: []complianceCase{
+			{expr: `foo[]."✓"`, result: `["✓", "✗"]`},
+			{expr: `"☯"`, result: `true`},
+			{expr: `"♪♫•*¨*•.¸¸❤¸¸.•*¨*•♫♪"`, result: `true`},
+		},
+	},
+	{
+		name: "wildcard",
+		given: `{"foo": {"bar": {"baz": "val"}, "other": {"baz": "val"}, "other2": {"baz": "val"},
+			"other3": {"notbaz": ["a", "b", "c"]}, "other4": {"notbaz": ["a", "b", "c"]}},
+			"list": [{"bar": "one"}, {"bar": "two"}, {"notbar": "three"}],
+			"str": "string", "nested": [[{"a": 1}, {"a": 2}], [{"a": 3}]]}`,
+		cases: []complianceCase{
+			{expr: `foo.*.baz`, result: `["val", "val", "val"]`},
+			{expr: `foo.bar.*`, result: `["val"]`},
+			{expr: `foo.*.notbaz`, result: `[["a", "b", "c"], ["a", "b", "c"]]`},
+			{expr: `foo.*.notbaz[0]`, result: `["a", "a"]`},
+			{expr: `foo.*.notbaz[-1]`, result: `["c", "c"]`},
+			{expr: `foo.*.missing`, result: `[]`},
+			{expr: `list[*].bar`, result: `["one", "two"]`},
+			{expr: `list[*]`, result: `[{"bar": "one"}, {"bar": "two"}, {"notbar": "three"}]`},
+			{expr: `list[*].notbar`, result: `["three"]`},
+			{expr: `list[*].missing`, result: `[]`},
+			{expr: `str[*]`, result: `null`},
+			{expr: `str.*`, result: `null`},
+			{expr: `nested[*][*].a`, result: `[[1, 2], [3]]`},
+			{expr: `nested[][*].a`, result: `[]`},
+			{expr: `nested[*][0].a`, result: `[1, 3]`},
+			{expr: `nested[].a`, result: `[1, 2, 3]`},
+		},
+	},
+}
+
+func TestCompliance(t *testing.T) {
+	for _, suite := range complianceSuites {
+		for _, tc := range suite.cases {
+			t.Run(suite.name+"/"+tc.expr, func(t *testing.T) {
+				if tc.skip != "" {
+					t.Skip(tc.skip)
+				}
+				// Each case gets its own copy, as go-jmespath's sort_by
+				// sorts the array it is given in place.
+				var given interface{}
+				if err := json.Unmarshal([]byte(suite.given), &given); err != nil {
+					t.Fatalf("invalid given: %v", err)
+				}
+				got, err := search(tc.expr, given)
+				switch tc.err {
+				case "syntax":
+					var syntaxErr *SyntaxError
+					if !errors.As(err, &syntaxErr) {
+						t.Fatalf("want syntax error, got %v (result %v)", err, got)
+					}
+					return
+				case "runtime":
+					if err == nil {
+						t.Fatalf("want error, got %v", got)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				var want interface{}
+				if err := json.Unmarshal([]byte(tc.result), &want); err != nil {
+					t.Fatalf("invalid result: %v", err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("got %#v, want %#v", got, want)
+				}
+			})
+		}
+	}
+}
+
+func search(expr string, data interface{}) (interface{}, error) {
+	e, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return e.Search(data)
+}
+
+func TestSearchConvertsStructs(t *testing.T) {
+	type item struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	got, err := Search("[?name == 'b'].id", []item{{"1", "a"}, {"2", "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []interface{}{"2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestSyntaxErrorOffset(t *testing.T) {
+	_, err := Compile("foo.bar.")
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("want syntax error, got %v", err)
+	}
+	if syntaxErr.Offset != len("foo.bar.") {
+		t.Errorf("offset = %d, want %d", syntaxErr.Offset, len("foo.bar."))
+	}
+}
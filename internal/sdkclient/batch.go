@@ -0,0 +1,66 @@
+package sdkclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MaxBatchSize is the most requests the API's /batch endpoint accepts in one
+// call.
+const MaxBatchSize = 50
+
+// BatchRequest is one request sent through the /batch endpoint. Path is
+// relative to the API base URL, e.g. "/subscribers/123".
+type BatchRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchResponse is the API's answer to one BatchRequest.
+type BatchResponse struct {
+	Code int             `json:"code"`
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// Err returns the response as a CLIError if it is an error response.
+func (r BatchResponse) Err() error {
+	if r.Code < 400 {
+		return nil
+	}
+	return errorFromBody(r.Code, r.Body)
+}
+
+// Batch sends reqs through the /batch endpoint, MaxBatchSize per call, and
+// returns one response per request in the same order.
+func Batch(ctx context.Context, httpClient *http.Client, apiKey string, reqs []BatchRequest) ([]BatchResponse, error) {
+	responses := make([]BatchResponse, 0, len(reqs))
+	for start := 0; start < len(reqs); start += MaxBatchSize {
+		chunk := reqs[start:min(start+MaxBatchSize, len(reqs))]
+
+		// The endpoint expects paths with the "api/" prefix of the base URL.
+		body := struct {
+			Requests []BatchRequest `json:"requests"`
+		}{Requests: make([]BatchRequest, len(chunk))}
+		for i, r := range chunk {
+			r.Method = strings.ToUpper(r.Method)
+			r.Path = "api/" + strings.TrimPrefix(strings.TrimPrefix(r.Path, "/"), "api/")
+			body.Requests[i] = r
+		}
+
+		var result struct {
+			Responses []BatchResponse `json:"responses"`
+		}
+		if _, err := DoRaw(ctx, httpClient, apiKey, http.MethodPost, "/batch", body, &result); err != nil {
+			return responses, err
+		}
+		if len(result.Responses) != len(chunk) {
+			return responses, fmt.Errorf("batch returned %d responses for %d requests", len(result.Responses), len(chunk))
+		}
+		responses = append(responses, result.Responses...)
+	}
+	return responses, nil
+}
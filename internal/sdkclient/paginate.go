@@ -1,114 +1,199 @@
 package sdkclient
 
-import "context"
+import (
+	"context"
+	"iter"
+)
+
+const (
+	// DefaultPageSize is the number of items requested per page when no
+	// page size is configured.
+	DefaultPageSize = 25
+	// MaxPageSize is the largest page size accepted by the MailerLite API.
+	MaxPageSize = 1000
+)
+
+// PageOptions controls how paginated results are fetched.
+type PageOptions struct {
+	// Limit is the maximum number of items to yield (0 = all).
+	Limit int
+	// PageSize is the number of items requested per page (0 = DefaultPageSize).
+	PageSize int
+	// Prefetch fetches the next page in the background while the current
+	// page is being consumed.
+	Prefetch bool
+}
+
+func (o PageOptions) perPage() int {
+	perPage := o.PageSize
+	if perPage <= 0 {
+		perPage = DefaultPageSize
+	}
+	if perPage > MaxPageSize {
+		perPage = MaxPageSize
+	}
+	if o.Limit > 0 && o.Limit < perPage {
+		perPage = o.Limit
+	}
+	return perPage
+}
 
 // PageFetcher fetches a single page of results. Returns the items, whether
 // there is a next page, and any error.
 type PageFetcher[T any] func(ctx context.Context, page, perPage int) ([]T, bool, error)
 
-// FetchAll fetches all pages up to limit using the given PageFetcher.
-// If limit is 0, all pages are fetched.
-func FetchAll[T any](ctx context.Context, fetch PageFetcher[T], limit int) ([]T, error) {
-	perPage := 25
-	if limit > 0 && limit < perPage {
-		perPage = limit
-	}
-
-	var allItems []T
-	page := 1
-
-	for {
-		items, hasNext, err := fetch(ctx, page, perPage)
-		if err != nil {
-			return nil, err
-		}
-
-		allItems = append(allItems, items...)
-
-		if limit > 0 && len(allItems) >= limit {
-			allItems = allItems[:limit]
-			break
+// Iterate yields items from all pages returned by the given PageFetcher,
+// requesting the next page only when the current one has been consumed
+// (or in the background, if opts.Prefetch is set).
+func Iterate[T any](ctx context.Context, fetch PageFetcher[T], opts PageOptions) iter.Seq2[T, error] {
+	return paginate(ctx, func() nextPageFunc[T] {
+		page := 1
+		return func(ctx context.Context, perPage int) ([]T, bool, error) {
+			items, hasNext, err := fetch(ctx, page, perPage)
+			page++
+			return items, hasNext, err
 		}
-
-		if !hasNext {
-			break
-		}
-		page++
-	}
-
-	return allItems, nil
+	}, opts)
 }
 
 // StringCursorFetcher fetches a single page using string cursor-based pagination.
 // Returns the items, the next cursor (empty if no more pages), and any error.
 type StringCursorFetcher[T any] func(ctx context.Context, cursor string, perPage int) ([]T, string, error)
 
-// FetchAllStringCursor fetches all pages using string cursor-based pagination.
+// IterateStringCursor is like Iterate for string cursor-based pagination.
 // Used for subscriber pagination in MailerLite.
-func FetchAllStringCursor[T any](ctx context.Context, fetch StringCursorFetcher[T], limit int) ([]T, error) {
-	perPage := 25
-	if limit > 0 && limit < perPage {
-		perPage = limit
-	}
-
-	var allItems []T
-	cursor := ""
-
-	for {
-		items, nextCursor, err := fetch(ctx, cursor, perPage)
-		if err != nil {
-			return nil, err
+func IterateStringCursor[T any](ctx context.Context, fetch StringCursorFetcher[T], opts PageOptions) iter.Seq2[T, error] {
+	return paginate(ctx, func() nextPageFunc[T] {
+		cursor := ""
+		return func(ctx context.Context, perPage int) ([]T, bool, error) {
+			items, next, err := fetch(ctx, cursor, perPage)
+			cursor = next
+			return items, next != "" && len(items) > 0, err
 		}
+	}, opts)
+}
 
-		allItems = append(allItems, items...)
+// CursorFetcher fetches a single page of results using cursor-based pagination.
+// Returns the items, the next cursor value (0 if no more pages), and any error.
+type CursorFetcher[T any] func(ctx context.Context, after, perPage int) ([]T, int, error)
 
-		if limit > 0 && len(allItems) >= limit {
-			allItems = allItems[:limit]
-			break
+// IterateCursor is like Iterate for cursor-based pagination (After int).
+// Used for segment subscriber pagination in MailerLite.
+func IterateCursor[T any](ctx context.Context, fetch CursorFetcher[T], opts PageOptions) iter.Seq2[T, error] {
+	return paginate(ctx, func() nextPageFunc[T] {
+		after := 0
+		return func(ctx context.Context, perPage int) ([]T, bool, error) {
+			items, next, err := fetch(ctx, after, perPage)
+			after = next
+			return items, next != 0 && len(items) > 0, err
 		}
+	}, opts)
+}
 
-		if nextCursor == "" || len(items) == 0 {
-			break
+// Collect drains an iterator into a slice, stopping at the first error.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
 		}
-		cursor = nextCursor
+		items = append(items, item)
 	}
+	return items, nil
+}
 
-	return allItems, nil
+// FetchAll fetches all pages up to limit using the given PageFetcher.
+// If limit is 0, all pages are fetched.
+func FetchAll[T any](ctx context.Context, fetch PageFetcher[T], limit int) ([]T, error) {
+	return Collect(Iterate(ctx, fetch, PageOptions{Limit: limit}))
 }
 
-// CursorFetcher fetches a single page of results using cursor-based pagination.
-// Returns the items, the next cursor value (0 if no more pages), and any error.
-type CursorFetcher[T any] func(ctx context.Context, after, perPage int) ([]T, int, error)
+// FetchAllStringCursor fetches all pages using string cursor-based pagination.
+func FetchAllStringCursor[T any](ctx context.Context, fetch StringCursorFetcher[T], limit int) ([]T, error) {
+	return Collect(IterateStringCursor(ctx, fetch, PageOptions{Limit: limit}))
+}
 
 // FetchAllCursor fetches all pages using cursor-based pagination (After int).
-// Used for segment subscriber pagination in MailerLite.
 func FetchAllCursor[T any](ctx context.Context, fetch CursorFetcher[T], limit int) ([]T, error) {
-	perPage := 25
-	if limit > 0 && limit < perPage {
-		perPage = limit
-	}
+	return Collect(IterateCursor(ctx, fetch, PageOptions{Limit: limit}))
+}
 
-	var allItems []T
-	after := 0
+// nextPageFunc fetches the page following the previous call. It is only
+// ever called sequentially, so implementations may keep cursor state; a new
+// one is created for every iteration so that iterators can be reused.
+type nextPageFunc[T any] func(ctx context.Context, perPage int) ([]T, bool, error)
 
-	for {
-		items, nextAfter, err := fetch(ctx, after, perPage)
-		if err != nil {
-			return nil, err
-		}
+type pageResult[T any] struct {
+	items   []T
+	hasNext bool
+	err     error
+}
 
-		allItems = append(allItems, items...)
+// paginate turns a stateful page fetcher into an item iterator that honours
+// opts.Limit and, optionally, fetches one page ahead.
+func paginate[T any](ctx context.Context, newNext func() nextPageFunc[T], opts PageOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		perPage := opts.perPage()
+		next := newNext()
 
-		if limit > 0 && len(allItems) >= limit {
-			allItems = allItems[:limit]
-			break
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var pages <-chan pageResult[T]
+		if opts.Prefetch {
+			pages = prefetchPages(ctx, next, perPage)
 		}
 
-		if nextAfter == 0 || len(items) == 0 {
-			break
+		yielded := 0
+		for {
+			var p pageResult[T]
+			if pages != nil {
+				var ok bool
+				if p, ok = <-pages; !ok {
+					return
+				}
+			} else {
+				p.items, p.hasNext, p.err = next(ctx, perPage)
+			}
+
+			if p.err != nil {
+				var zero T
+				yield(zero, p.err)
+				return
+			}
+			for _, item := range p.items {
+				if !yield(item, nil) {
+					return
+				}
+				yielded++
+				if opts.Limit > 0 && yielded >= opts.Limit {
+					return
+				}
+			}
+			if !p.hasNext {
+				return
+			}
 		}
-		after = nextAfter
 	}
+}
 
-	return allItems, nil
+// prefetchPages fetches pages in a background goroutine. The channel is
+// unbuffered, so at most one page is fetched ahead of the consumer.
+func prefetchPages[T any](ctx context.Context, next nextPageFunc[T], perPage int) <-chan pageResult[T] {
+	ch := make(chan pageResult[T])
+	go func() {
+		defer close(ch)
+		for {
+			items, hasNext, err := next(ctx, perPage)
+			select {
+			case ch <- pageResult[T]{items, hasNext, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil || !hasNext {
+				return
+			}
+		}
+	}()
+	return ch
 }
@@ -0,0 +1,158 @@
+package sdkclient
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerMinute is the API's documented rate limit.
+const DefaultRequestsPerMinute = 120
+
+// RateLimiter is a token bucket shared by every request of the process. It
+// starts from a configured rate and adapts to the rate-limit headers of
+// each response, so concurrent requests slow down before the API starts
+// answering 429. The configured rate is a ceiling: a higher server limit
+// never speeds requests up beyond it.
+type RateLimiter struct {
+	mu           sync.Mutex
+	configured   int     // requests per minute
+	rate         float64 // tokens per second
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	now          func() time.Time
+}
+
+// NewRateLimiter returns a limiter allowing perMinute requests per minute,
+// in bursts of up to a tenth of that.
+func NewRateLimiter(perMinute int) *RateLimiter {
+	l := &RateLimiter{configured: perMinute, now: time.Now}
+	l.setLimit(perMinute)
+	l.tokens = l.burst
+	l.last = l.now()
+	return l
+}
+
+func (l *RateLimiter) setLimit(perMinute int) {
+	l.rate = float64(perMinute) / 60
+	l.burst = max(1, math.Floor(float64(perMinute)/10))
+}
+
+// Wait blocks until a request may be sent and returns how long it waited.
+// Waiting callers reserve their token up front, so they are served in
+// order.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	now := l.now()
+	l.refill(now)
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if blocked := l.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return 0, nil
+	}
+	return wait, sleep(ctx, wait)
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+	}
+	l.last = now
+}
+
+// Observe adapts the limiter to a response's X-RateLimit-Limit,
+// X-RateLimit-Remaining and, for a 429, Retry-After headers.
+func (l *RateLimiter) Observe(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.refill(now)
+	if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil && limit > 0 {
+		if limit = min(limit, l.configured); float64(limit)/60 != l.rate {
+			l.setLimit(limit)
+		}
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		l.tokens = min(l.tokens, float64(remaining))
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.tokens = min(l.tokens, 0)
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			if until := now.Add(retryAfter); until.After(l.blockedUntil) {
+				l.blockedUntil = until
+			}
+		}
+	}
+}
+
+// RetryPolicy controls how failed requests are retried. Network errors, 429
+// and 5xx responses are retried with exponential backoff and full jitter,
+// or after the response's Retry-After. Retrying stops after MaxRetries
+// attempts or once the total wait would exceed MaxWait.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	MaxWait    time.Duration
+}
+
+// DefaultRetryPolicy is used by transports without a policy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 5,
+	BaseDelay:  time.Second,
+	MaxDelay:   30 * time.Second,
+	MaxWait:    5 * time.Minute,
+}
+
+// backoff returns the jittered delay before retry number attempt+1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := min(float64(p.BaseDelay)*math.Pow(2, float64(attempt)), float64(p.MaxDelay))
+	return time.Duration(rand.Float64() * d)
+}
+
+// delay returns the wait before retrying resp (nil for a network error).
+// Retry-After is honoured, plus up to a second of jitter so concurrent
+// requests don't all retry at once.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			return retryAfter + time.Duration(rand.Float64()*float64(time.Second))
+		}
+	}
+	return p.backoff(attempt)
+}
+
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,92 @@
+package sdkclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock for RateLimiter that only moves when told to.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(perMinute int) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewRateLimiter(perMinute)
+	l.now = clock.now
+	l.last = clock.now()
+	return l, clock
+}
+
+// waitFor returns how long Wait would block. The context is cancelled so
+// that it returns at once instead of sleeping.
+func waitFor(t *testing.T, l *RateLimiter) time.Duration {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	wait, _ := l.Wait(ctx)
+	return wait
+}
+
+func rateLimitResponse(status, limit int, retryAfter string) *http.Response {
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	if retryAfter != "" {
+		h.Set("Retry-After", retryAfter)
+	}
+	return &http.Response{StatusCode: status, Header: h}
+}
+
+func TestRateLimiterKeepsConfiguredRateAsCeiling(t *testing.T) {
+	tests := []struct {
+		name        string
+		configured  int
+		serverLimit int
+		want        float64 // requests per minute
+	}{
+		{"higher server limit", 60, 120, 60},
+		{"lower server limit", 120, 30, 30},
+		{"same limit", 120, 120, 120},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, _ := newTestLimiter(tt.configured)
+			l.Observe(rateLimitResponse(http.StatusOK, tt.serverLimit, ""))
+			if got := l.rate * 60; got != tt.want {
+				t.Errorf("rate = %v requests per minute, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l, clock := newTestLimiter(60)
+
+	// The burst is a tenth of the per-minute rate.
+	for i := 0; i < 6; i++ {
+		if wait := waitFor(t, l); wait != 0 {
+			t.Fatalf("request %d waited %s within the burst", i+1, wait)
+		}
+	}
+	if wait := waitFor(t, l); wait != time.Second {
+		t.Errorf("request after the burst waited %s, want 1s", wait)
+	}
+
+	clock.advance(time.Minute)
+	l.Observe(rateLimitResponse(http.StatusTooManyRequests, 60, "5"))
+	if wait := waitFor(t, l); wait != 5*time.Second {
+		t.Errorf("request after a 429 waited %s, want the Retry-After of 5s", wait)
+	}
+	clock.advance(3 * time.Second)
+	if wait := waitFor(t, l); wait != 2*time.Second {
+		t.Errorf("request 3s after a 429 waited %s, want 2s", wait)
+	}
+	clock.advance(time.Minute)
+	if wait := waitFor(t, l); wait != 0 {
+		t.Errorf("request once Retry-After passed waited %s, want none", wait)
+	}
+}
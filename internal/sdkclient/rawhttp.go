@@ -6,16 +6,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
-const rawDefaultBaseURL = "https://connect.mailerlite.com/api"
-
 // DoRaw performs a raw HTTP request for endpoints not covered by the SDK
 // (e.g., e-commerce). It uses the provided httpClient (which should have
-// the CLITransport configured) for retry/verbose behavior.
+// the CLITransport configured) for retry/verbose behavior and base URL
+// rewriting.
 func DoRaw(ctx context.Context, httpClient *http.Client, apiKey, method, path string, body, result interface{}) (*http.Response, error) {
-	url := rawDefaultBaseURL + path
+	return DoRawWithHeaders(ctx, httpClient, apiKey, method, path, nil, body, result)
+}
+
+// DoRawWithHeaders is like DoRaw but also sends the given headers, which
+// override the defaults.
+func DoRawWithHeaders(ctx context.Context, httpClient *http.Client, apiKey, method, path string, header http.Header, body, result interface{}) (*http.Response, error) {
+	url := DefaultBaseURL + path
 
 	var bodyReader io.Reader
 	if body != nil {
@@ -34,6 +42,9 @@ func DoRaw(ctx context.Context, httpClient *http.Client, apiKey, method, path st
 	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	for k, v := range header {
+		req.Header[http.CanonicalHeaderKey(k)] = v
+	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -43,22 +54,7 @@ func DoRaw(ctx context.Context, httpClient *http.Client, apiKey, method, path st
 
 	if resp.StatusCode >= 400 {
 		respBody, _ := io.ReadAll(resp.Body)
-		cliErr := &CLIError{StatusCode: resp.StatusCode}
-		if len(respBody) > 0 {
-			cliErr.RawBody = respBody
-			var parsed struct {
-				Message string              `json:"message"`
-				Errors  map[string][]string `json:"errors"`
-			}
-			if json.Unmarshal(respBody, &parsed) == nil {
-				cliErr.Message = parsed.Message
-				cliErr.Errors = parsed.Errors
-			}
-		}
-		if cliErr.Message == "" {
-			cliErr.Message = fmt.Sprintf("HTTP %d", resp.StatusCode)
-		}
-		return resp, cliErr
+		return resp, errorFromBody(resp.StatusCode, respBody)
 	}
 
 	if result != nil && resp.StatusCode != http.StatusNoContent {
@@ -81,3 +77,90 @@ func DoRawQuery(ctx context.Context, httpClient *http.Client, apiKey, method, pa
 	}
 	return DoRaw(ctx, httpClient, apiKey, method, path, body, result)
 }
+
+// errorFromBody builds a CLIError from an error response's status and body.
+func errorFromBody(status int, body []byte) *CLIError {
+	cliErr := &CLIError{StatusCode: status}
+	if len(body) > 0 {
+		cliErr.RawBody = body
+		var parsed struct {
+			Message string              `json:"message"`
+			Errors  map[string][]string `json:"errors"`
+		}
+		if json.Unmarshal(body, &parsed) == nil {
+			cliErr.Message = parsed.Message
+			cliErr.Errors = parsed.Errors
+		}
+	}
+	if cliErr.Message == "" {
+		cliErr.Message = fmt.Sprintf("HTTP %d", status)
+	}
+	return cliErr
+}
+
+// RelativePath returns p as a path relative to the API base URL. Full URLs,
+// such as pagination links, and paths starting with "api/" are accepted too.
+func RelativePath(p string) (string, error) {
+	if strings.Contains(p, "://") {
+		u, err := url.Parse(p)
+		if err != nil {
+			return "", fmt.Errorf("invalid URL %q: %w", p, err)
+		}
+		p = u.Path
+		if i := strings.Index(p, "/api/"); i >= 0 {
+			p = p[i+len("/api"):]
+		}
+		if u.RawQuery != "" {
+			p += "?" + u.RawQuery
+		}
+	}
+	p = strings.TrimPrefix(strings.TrimPrefix(p, "/"), "api/")
+	return "/" + p, nil
+}
+
+// IterateRaw GETs path and yields the items of its data array as they are,
+// following links.next or meta.next_cursor to the next page until a page is
+// empty or has no successor.
+func IterateRaw(ctx context.Context, httpClient *http.Client, apiKey, path string, header http.Header) iter.Seq2[json.RawMessage, error] {
+	return func(yield func(json.RawMessage, error) bool) {
+		for path != "" {
+			var page struct {
+				Data  []json.RawMessage `json:"data"`
+				Links struct {
+					Next string `json:"next"`
+				} `json:"links"`
+				Meta struct {
+					NextCursor string `json:"next_cursor"`
+				} `json:"meta"`
+			}
+			if _, err := DoRawWithHeaders(ctx, httpClient, apiKey, http.MethodGet, path, header, nil, &page); err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			switch {
+			case len(page.Data) == 0:
+				path = ""
+			case page.Links.Next != "":
+				next, err := RelativePath(page.Links.Next)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				path = next
+			case page.Meta.NextCursor != "":
+				base, rawQuery, _ := strings.Cut(path, "?")
+				query, _ := url.ParseQuery(rawQuery)
+				query.Set("cursor", page.Meta.NextCursor)
+				path = base + "?" + query.Encode()
+			default:
+				path = ""
+			}
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package sdkclient
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mailerlite/mailerlite-go"
+)
+
+// SubscriberID returns the ID of the subscriber identified by idOrEmail. IDs
+// are returned as-is; email addresses are looked up.
+func SubscriberID(ctx context.Context, ml *mailerlite.Client, idOrEmail string) (string, error) {
+	if !strings.Contains(idOrEmail, "@") {
+		return idOrEmail, nil
+	}
+	root, _, err := ml.Subscriber.Get(ctx, &mailerlite.GetSubscriberOptions{Email: idOrEmail})
+	if err != nil {
+		return "", WrapError(err)
+	}
+	return root.Data.ID, nil
+}
@@ -2,21 +2,24 @@ package sdkclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
-	"math"
+	"log/slog"
 	"net/http"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
-)
 
-const (
-	defaultBaseURL = "https://connect.mailerlite.com/api"
-	maxRetries     = 3
+	"github.com/mailerlite/mailerlite-cli/internal/logging"
 )
 
+// DefaultBaseURL is the production API base URL. Requests to it are
+// rewritten to CLITransport.BaseURL when that is set.
+const DefaultBaseURL = "https://connect.mailerlite.com/api"
+
 var userAgent = "mailerlite-cli/dev"
 
 // SetUserAgent sets the User-Agent string used for all API requests.
@@ -25,15 +28,20 @@ func SetUserAgent(ua string) {
 }
 
 // CLITransport wraps an http.RoundTripper with CLI-specific behavior:
-// retry logic, verbose logging, user-agent override, base URL rewrite,
+// rate limiting, retry logic, debug logging, user-agent override, base URL rewrite,
 // and error body capture for the error bridge.
 type CLITransport struct {
 	Base      http.RoundTripper
-	Verbose   bool
-	BaseURL   string // if set, replaces the SDK's hardcoded base URL
-	AccountID string // if set, sends X-Acc-Id header on all requests
+	Logger    *slog.Logger // if set, logs each request, response and retry
+	BaseURL   string       // if set, replaces the SDK's hardcoded base URL
+	AccountID string       // if set, sends X-Acc-Id header on all requests
+	Limiter   *RateLimiter // if set, throttles requests before they are sent
+	Retry     RetryPolicy  // zero value means DefaultRetryPolicy
 }
 
+// maxLoggedBody is the number of body bytes included in log records.
+const maxLoggedBody = 4096
+
 func (t *CLITransport) base() http.RoundTripper {
 	if t.Base != nil {
 		return t.Base
@@ -45,8 +53,8 @@ func (t *CLITransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// Rewrite base URL if configured.
 	if t.BaseURL != "" {
 		urlStr := req.URL.String()
-		if strings.HasPrefix(urlStr, defaultBaseURL) {
-			newURL := t.BaseURL + strings.TrimPrefix(urlStr, defaultBaseURL)
+		if strings.HasPrefix(urlStr, DefaultBaseURL) {
+			newURL := t.BaseURL + strings.TrimPrefix(urlStr, DefaultBaseURL)
 			parsed, err := req.URL.Parse(newURL)
 			if err == nil {
 				req.URL = parsed
@@ -73,20 +81,15 @@ func (t *CLITransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 	}
 
-	if t.Verbose {
-		fmt.Printf("--> %s %s\n", req.Method, req.URL)
-		if t.AccountID != "" {
-			fmt.Printf("--> X-Acc-Id: %s\n", t.AccountID)
-		}
-		if len(bodyBytes) > 0 {
-			fmt.Printf("--> body: %s\n", string(bodyBytes))
-		}
+	policy := t.Retry
+	if policy == (RetryPolicy{}) {
+		policy = DefaultRetryPolicy
 	}
+	ctx := req.Context()
 
-	var resp *http.Response
-	var lastErr error
+	var waited time.Duration
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
+	for attempt := 0; ; attempt++ {
 		if attempt > 0 {
 			// Reset body for retry.
 			if len(bodyBytes) > 0 {
@@ -94,32 +97,74 @@ func (t *CLITransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			}
 		}
 
-		resp, lastErr = t.base().RoundTrip(req)
+		if t.Limiter != nil {
+			wait, err := t.Limiter.Wait(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if wait > 0 {
+				t.log(slog.LevelDebug, "rate limited",
+					slog.String("method", req.Method),
+					slog.String("url", req.URL.String()),
+					slog.Duration("wait", wait))
+			}
+		}
+
+		t.log(slog.LevelDebug, "http request",
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Int("attempt", attempt+1),
+			slog.String("account_id", t.AccountID),
+			slog.String("body", logBody(bodyBytes)))
+
+		start := time.Now()
+		resp, lastErr := t.base().RoundTrip(req)
+		elapsed := time.Since(start)
 		if lastErr != nil {
-			if t.Verbose {
-				fmt.Printf("<-- error: %v\n", lastErr)
+			t.log(slog.LevelDebug, "http error",
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.String()),
+				slog.Int("attempt", attempt+1),
+				slog.Duration("duration", elapsed),
+				slog.String("error", lastErr.Error()))
+			if errors.Is(lastErr, ErrNotRecorded) || ctx.Err() != nil {
+				return nil, lastErr
+			}
+			wait := policy.delay(attempt, nil)
+			if attempt >= policy.MaxRetries || waited+wait > policy.MaxWait {
+				return nil, fmt.Errorf("request failed after %d retries: %w", attempt, lastErr)
 			}
-			if attempt == maxRetries {
-				break
+			t.logRetry(req, attempt, wait, "network error")
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
 			}
-			backoff := time.Duration(math.Pow(2, float64(attempt))) * time.Second
-			time.Sleep(backoff)
+			waited += wait
 			continue
 		}
-
-		if t.Verbose {
-			fmt.Printf("<-- %d %s\n", resp.StatusCode, resp.Status)
+		if t.Limiter != nil {
+			t.Limiter.Observe(resp)
 		}
 
-		// Capture error response body before the SDK can consume it.
-		if resp.StatusCode >= 400 {
-			respBody, _ := io.ReadAll(resp.Body)
+		// Read the body up front: error bodies are captured for WrapError,
+		// and both kinds are logged.
+		var respBody []byte
+		if resp.StatusCode >= 400 || t.Logger != nil {
+			respBody, _ = io.ReadAll(resp.Body)
 			resp.Body.Close() //nolint:errcheck
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		}
+		t.log(slog.LevelDebug, "http response",
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Int("status", resp.StatusCode),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("duration", elapsed),
+			slog.String("rate_limit", resp.Header.Get("X-RateLimit-Limit")),
+			slog.String("rate_limit_remaining", resp.Header.Get("X-RateLimit-Remaining")),
+			slog.String("retry_after", resp.Header.Get("Retry-After")),
+			slog.String("body", logBody(respBody)))
 
-			if t.Verbose && len(respBody) > 0 {
-				fmt.Printf("<-- body: %s\n", string(respBody))
-			}
-
+		if resp.StatusCode >= 400 {
 			// Store for WrapError.
 			if resp.Header == nil {
 				resp.Header = make(http.Header)
@@ -127,42 +172,51 @@ func (t *CLITransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			resp.Header.Set("X-CLI-Error-Body", base64.StdEncoding.EncodeToString(respBody))
 
 			// For retryable errors, retry.
-			if resp.StatusCode == 429 || resp.StatusCode >= 500 {
-				wait := time.Duration(math.Pow(2, float64(attempt))) * time.Second
-				if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-					if secs, err := strconv.Atoi(retryAfter); err == nil {
-						wait = time.Duration(secs) * time.Second
-					}
-				}
-				if attempt < maxRetries {
-					if t.Verbose {
-						fmt.Printf("    retrying in %s...\n", wait)
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+				wait := policy.delay(attempt, resp)
+				if attempt < policy.MaxRetries && waited+wait <= policy.MaxWait {
+					t.logRetry(req, attempt, wait, resp.Status)
+					if err := sleep(ctx, wait); err != nil {
+						return nil, err
 					}
-					time.Sleep(wait)
+					waited += wait
 					continue
 				}
 			}
-
-			// Replace the body so the SDK can still read it.
-			resp.Body = io.NopCloser(bytes.NewReader(respBody))
-			return resp, nil
-		}
-
-		// Success — capture body for verbose logging, then re-wrap.
-		if t.Verbose {
-			respBody, _ := io.ReadAll(resp.Body)
-			resp.Body.Close() //nolint:errcheck
-			if len(respBody) > 0 {
-				fmt.Printf("<-- body: %s\n", string(respBody))
-			}
-			resp.Body = io.NopCloser(bytes.NewReader(respBody))
 		}
 
 		return resp, nil
 	}
+}
+
+// log writes a record to t.Logger, if set, leaving out empty string
+// attributes.
+func (t *CLITransport) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if t.Logger == nil {
+		return
+	}
+	attrs = slices.DeleteFunc(attrs, func(a slog.Attr) bool {
+		return a.Value.Kind() == slog.KindString && a.Value.String() == ""
+	})
+	t.Logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+func (t *CLITransport) logRetry(req *http.Request, attempt int, wait time.Duration, reason string) {
+	t.log(slog.LevelWarn, "retrying request",
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Int("attempt", attempt+1),
+		slog.Duration("wait", wait),
+		slog.String("reason", reason))
+}
 
-	if lastErr != nil {
-		return nil, fmt.Errorf("request failed after %d retries: %w", maxRetries, lastErr)
+// logBody returns a request or response body for logging, redacted and then
+// truncated to maxLoggedBody bytes. Redacting first keeps an email address
+// cut at the limit from escaping the pattern that masks it.
+func logBody(body []byte) string {
+	s := logging.Redact(string(body))
+	if len(s) <= maxLoggedBody {
+		return s
 	}
-	return resp, nil
+	return fmt.Sprintf("%s... (%d more bytes)", s[:maxLoggedBody], len(s)-maxLoggedBody)
 }
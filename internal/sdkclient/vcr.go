@@ -0,0 +1,287 @@
+package sdkclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrNotRecorded is returned in replay mode for a request that has no
+// recorded response. It is never retried.
+var ErrNotRecorded = errors.New("no recorded response")
+
+// redactedHeaders are replaced with "REDACTED" in recorded fixtures.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Interaction is one recorded request/response pair, stored as a JSON
+// fixture file. Bodies that are valid JSON are stored as JSON, other bodies
+// as text.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method  string          `json:"method"`
+	URL     string          `json:"url"`
+	Headers http.Header     `json:"headers,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+	Text    string          `json:"body_text,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int             `json:"status_code"`
+	Headers    http.Header     `json:"headers,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	Text       string          `json:"body_text,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that saves every request and its response
+// as a numbered fixture in Dir. Fixtures are appended to those already in
+// Dir, so several commands can be recorded into one directory.
+type Recorder struct {
+	Base http.RoundTripper
+	Dir  string
+
+	mu sync.Mutex
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	base := r.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close() //nolint:errcheck
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	in := Interaction{
+		Request:  RecordedRequest{Method: req.Method, URL: req.URL.String(), Headers: redact(req.Header)},
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Headers: redact(resp.Header)},
+	}
+	in.Request.Body, in.Request.Text = splitBody(reqBody)
+	in.Response.Body, in.Response.Text = splitBody(respBody)
+	if err := r.save(in); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes in to the next free fixture number in r.Dir.
+func (r *Recorder) save(in Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.Dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create record directory: %w", err)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(in); err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	existing, err := fixtureFiles(r.Dir)
+	if err != nil {
+		return err
+	}
+	for n := len(existing) + 1; ; n++ {
+		name := filepath.Join(r.Dir, fmt.Sprintf("%04d-%s-%s.json", n, strings.ToLower(in.Request.Method), fixtureSlug(in.Request.URL)))
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write fixture: %w", err)
+		}
+		_, err = f.Write(buf.Bytes())
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write fixture: %w", err)
+		}
+		return nil
+	}
+}
+
+// Replayer is an http.RoundTripper that answers requests from the fixtures
+// in a directory without touching the network. Requests are matched on
+// method, path and query; identical requests get their recorded responses
+// in order, and the last one again once those run out.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer loads the fixtures in dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	files, err := fixtureFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no fixtures found in %s", dir)
+	}
+
+	r := &Replayer{}
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		var in Interaction
+		if err := json.Unmarshal(data, &in); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", filepath.Base(name), err)
+		}
+		r.interactions = append(r.interactions, in)
+	}
+	r.used = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close() //nolint:errcheck
+	}
+	key := req.Method + " " + req.URL.RequestURI()
+
+	r.mu.Lock()
+	match := -1
+	for i, in := range r.interactions {
+		if interactionKey(in) != key {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match >= 0 {
+		r.used[match] = true
+	}
+	r.mu.Unlock()
+
+	if match < 0 {
+		return nil, fmt.Errorf("%w for %s", ErrNotRecorded, key)
+	}
+
+	rec := r.interactions[match].Response
+	body := []byte(rec.Text)
+	if len(rec.Body) > 0 {
+		body = rec.Body
+	}
+	header := rec.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func interactionKey(in Interaction) string {
+	req, err := http.NewRequest(in.Request.Method, in.Request.URL, nil)
+	if err != nil {
+		return ""
+	}
+	return in.Request.Method + " " + req.URL.RequestURI()
+}
+
+// fixtureFiles returns the fixture files in dir in recording order. A
+// missing directory has none.
+func fixtureFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "[0-9][0-9][0-9][0-9]*-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// fixtureSlug names a fixture after the last segments of its URL path.
+func fixtureSlug(rawURL string) string {
+	path := rawURL
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	path = strings.TrimPrefix(path, DefaultBaseURL)
+	if i := strings.Index(path, "/api/"); i >= 0 {
+		path = path[i+len("/api/"):]
+	}
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(path), "-"), "-")
+	if len(slug) > 60 {
+		slug = strings.Trim(slug[len(slug)-60:], "-")
+	}
+	if slug == "" {
+		slug = "root"
+	}
+	return slug
+}
+
+func redact(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := h.Clone()
+	for _, k := range redactedHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, "REDACTED")
+		}
+	}
+	out.Del("X-CLI-Error-Body")
+	return out
+}
+
+// splitBody returns body as JSON if it is valid JSON, otherwise as text.
+func splitBody(body []byte) (json.RawMessage, string) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, ""
+	}
+	if json.Valid(body) {
+		var buf bytes.Buffer
+		if json.Compact(&buf, body) == nil {
+			return buf.Bytes(), ""
+		}
+	}
+	return nil, string(body)
+}
@@ -0,0 +1,69 @@
+package sdkclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplayer(t *testing.T) {
+	dir := t.TempDir()
+	fixtures := []Interaction{
+		{Request: RecordedRequest{Method: "GET", URL: "https://connect.mailerlite.com/api/groups?page=1"}, Response: RecordedResponse{StatusCode: 200, Text: "page 1"}},
+		{Request: RecordedRequest{Method: "GET", URL: "https://connect.mailerlite.com/api/groups?page=2"}, Response: RecordedResponse{StatusCode: 200, Text: "page 2"}},
+		{Request: RecordedRequest{Method: "GET", URL: "https://connect.mailerlite.com/api/groups?page=1"}, Response: RecordedResponse{StatusCode: 429, Text: "page 1 again"}},
+		{Request: RecordedRequest{Method: "POST", URL: "https://connect.mailerlite.com/api/groups?page=1"}, Response: RecordedResponse{StatusCode: 201, Text: "created"}},
+	}
+	for i, in := range fixtures {
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := filepath.Join(dir, fmt.Sprintf("%04d-groups.json", i+1))
+		if err := os.WriteFile(name, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method, url string
+		status      int
+		body        string
+	}{
+		// The host is ignored; method, path and query must match.
+		{"GET", "http://localhost:8080/api/groups?page=1", 200, "page 1"},
+		{"GET", "http://localhost:8080/api/groups?page=1", 429, "page 1 again"},
+		// Once the recorded responses run out, the last one is repeated.
+		{"GET", "http://localhost:8080/api/groups?page=1", 429, "page 1 again"},
+		{"GET", "http://localhost:8080/api/groups?page=2", 200, "page 2"},
+		{"POST", "http://localhost:8080/api/groups?page=1", 201, "created"},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, tt.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := r.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.method, tt.url, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != tt.status || string(body) != tt.body {
+			t.Errorf("%s %s: got %d %q, want %d %q", tt.method, tt.url, resp.StatusCode, body, tt.status, tt.body)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", "http://localhost:8080/api/groups?page=3", nil)
+	if _, err := r.RoundTrip(req); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("unrecorded request: got %v, want ErrNotRecorded", err)
+	}
+}
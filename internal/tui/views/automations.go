@@ -98,7 +98,7 @@ func (v AutomationsView) Fetch() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		automations, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Automation, bool, error) {
+		automations, err := sdkclient.Collect(sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Automation, bool, error) {
 			root, _, err := v.client.Automation.List(ctx, &mailerlite.ListAutomationOptions{
 				Page:  page,
 				Limit: perPage,
@@ -107,7 +107,7 @@ func (v AutomationsView) Fetch() tea.Cmd {
 				return nil, false, sdkclient.WrapError(err)
 			}
 			return root.Data, root.Links.Next != "", nil
-		}, 100)
+		}, listPageOptions))
 
 		return types.AutomationsLoadedMsg{
 			Automations: automations,
@@ -90,7 +90,7 @@ func (v CampaignsView) Fetch() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		campaigns, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Campaign, bool, error) {
+		campaigns, err := sdkclient.Collect(sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Campaign, bool, error) {
 			root, _, err := v.client.Campaign.List(ctx, &mailerlite.ListCampaignOptions{
 				Page:  page,
 				Limit: perPage,
@@ -99,7 +99,7 @@ func (v CampaignsView) Fetch() tea.Cmd {
 				return nil, false, sdkclient.WrapError(err)
 			}
 			return root.Data, root.Links.Next != "", nil
-		}, 100)
+		}, listPageOptions))
 
 		return types.CampaignsLoadedMsg{
 			Campaigns: campaigns,
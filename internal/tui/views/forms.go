@@ -146,7 +146,7 @@ func (v FormsView) Fetch() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		forms, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Form, bool, error) {
+		forms, err := sdkclient.Collect(sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Form, bool, error) {
 			root, _, err := v.client.Form.List(ctx, &mailerlite.ListFormOptions{
 				Type:  v.activeTab.APIValue(),
 				Page:  page,
@@ -156,7 +156,7 @@ func (v FormsView) Fetch() tea.Cmd {
 				return nil, false, sdkclient.WrapError(err)
 			}
 			return root.Data, root.Links.Next != "", nil
-		}, 100)
+		}, listPageOptions))
 
 		return types.FormsLoadedMsg{
 			Forms: forms,
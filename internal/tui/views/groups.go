@@ -90,7 +90,7 @@ func (v GroupsView) Fetch() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		groups, err := sdkclient.FetchAll(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Group, bool, error) {
+		groups, err := sdkclient.Collect(sdkclient.Iterate(ctx, func(ctx context.Context, page, perPage int) ([]mailerlite.Group, bool, error) {
 			root, _, err := v.client.Group.List(ctx, &mailerlite.ListGroupOptions{
 				Page:  page,
 				Limit: perPage,
@@ -99,7 +99,7 @@ func (v GroupsView) Fetch() tea.Cmd {
 				return nil, false, sdkclient.WrapError(err)
 			}
 			return root.Data, root.Links.Next != "", nil
-		}, 100)
+		}, listPageOptions))
 
 		return types.GroupsLoadedMsg{
 			Groups: groups,
@@ -14,6 +14,9 @@ import (
 	"github.com/mailerlite/mailerlite-go"
 )
 
+// listPageOptions loads the first 100 items of each list in one request.
+var listPageOptions = sdkclient.PageOptions{Limit: 100, PageSize: 100}
+
 var (
 	checkStyle = lipgloss.NewStyle().Foreground(theme.Success)
 	crossStyle = lipgloss.NewStyle().Foreground(theme.Error)
@@ -114,7 +117,7 @@ func (v SubscribersView) Fetch() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		subscribers, err := sdkclient.FetchAllStringCursor(ctx, func(ctx context.Context, cursor string, perPage int) ([]mailerlite.Subscriber, string, error) {
+		subscribers, err := sdkclient.Collect(sdkclient.IterateStringCursor(ctx, func(ctx context.Context, cursor string, perPage int) ([]mailerlite.Subscriber, string, error) {
 			root, _, err := v.client.Subscriber.List(ctx, &mailerlite.ListSubscriberOptions{
 				Cursor: cursor,
 				Limit:  perPage,
@@ -123,7 +126,7 @@ func (v SubscribersView) Fetch() tea.Cmd {
 				return nil, "", sdkclient.WrapError(err)
 			}
 			return root.Data, root.Meta.NextCursor, nil
-		}, 100)
+		}, listPageOptions))
 
 		return types.SubscribersLoadedMsg{
 			Subscribers: subscribers,
@@ -12,6 +12,10 @@ import (
 func main() {
 	if err := cmd.Execute(); err != nil {
 		var cliErr *sdkclient.CLIError
+		if errors.Is(err, cmd.ErrInterrupted) {
+			output.Error(err.Error())
+			os.Exit(130)
+		}
 		if errors.As(err, &cliErr) && cmd.IsJSON() && len(cliErr.RawBody) > 0 {
 			_ = output.JSON(cliErr.RawBody)
 		} else {